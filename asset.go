@@ -2,22 +2,31 @@ package uipath
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 )
 
 const (
-	ValueTypeText       = "Text"
-	ValueTypeInteger    = "Integer"
-	ValueTypeBool       = "Bool"
-	ValueTypeCredential = "Credential"
-
-	ValueScopeGlobal = "Global"
-
-	AssetEndpoint = "Assets"
+	ValueTypeText               = "Text"
+	ValueTypeInteger            = "Integer"
+	ValueTypeBool               = "Bool"
+	ValueTypeCredential         = "Credential"
+	ValueTypeSecret             = "Secret"
+	ValueTypeDBConnectionString = "DBConnectionString"
+
+	ValueScopeGlobal   = "Global"
+	ValueScopePerRobot = "PerRobot"
+
+	AssetEndpoint                   = "Assets"
+	AssetShareToFoldersEndpoint     = "Assets/UiPath.Server.Configuration.OData.ShareToFolders"
+	AssetGetFoldersForAssetEndpoint = "Assets/UiPath.Server.Configuration.OData.GetFoldersForAsset"
 )
 
+// ErrAssetValueType is returned when reading an asset value with a getter of another value type
+var ErrAssetValueType = errors.New("asset value type mismatch")
+
 // AssetHandler struct defines what the asset handler looks like
 type AssetHandler struct {
 	Client   *Client
@@ -26,24 +35,59 @@ type AssetHandler struct {
 
 // Asset struct defines what the asset model looks like
 type Asset struct {
-	ID                 uint     `json:"Id"`
-	Name               string   `json:"Name"`
-	CanBeDeleted       bool     `json:"CanBeDeleted,omitempty"`
-	ValueScope         string   `json:"ValueScope,omitempty"`
-	ValueType          string   `json:"ValueType"`
-	Value              string   `json:"Value,omitempty"`
-	StringValue        string   `json:"StringValue,omitempty"`
-	BoolValue          bool     `json:"BoolValue,omitempty"`
-	IntValue           int      `json:"IntValue,omitempty"`
-	CredentialUsername string   `json:"CredentialUsername,omitempty"`
-	CredentialPassword string   `json:"CredentialPassword,omitempty"`
-	ExternalName       string   `json:"ExternalName,omitempty"`
-	CredentialStoreId  int      `json:"CredentialStoreId,omitempty"`
-	HasDefaultValue    bool     `json:"HasDefault,omitempty"`
-	Description        string   `json:"Description,omitempty"`
-	FolderCount        int      `json:"FolderCount,omitempty"`
-	Tags               []string `json:"Tag,omitempty"`
-	KeyValueList       []string `json:"KeyValueList,omitempty"`
+	ID                 uint              `json:"Id"`
+	Name               string            `json:"Name"`
+	CanBeDeleted       bool              `json:"CanBeDeleted,omitempty"`
+	ValueScope         string            `json:"ValueScope,omitempty"`
+	ValueType          string            `json:"ValueType"`
+	Value              string            `json:"Value,omitempty"`
+	StringValue        string            `json:"StringValue,omitempty"`
+	BoolValue          bool              `json:"BoolValue,omitempty"`
+	IntValue           int               `json:"IntValue,omitempty"`
+	CredentialUsername string            `json:"CredentialUsername,omitempty"`
+	CredentialPassword string            `json:"CredentialPassword,omitempty"`
+	SecretValue        string            `json:"SecretValue,omitempty"`
+	ExternalName       string            `json:"ExternalName,omitempty"`
+	CredentialStoreId  int               `json:"CredentialStoreId,omitempty"`
+	HasDefaultValue    bool              `json:"HasDefault,omitempty"`
+	Description        string            `json:"Description,omitempty"`
+	FolderCount        int               `json:"FolderCount,omitempty"`
+	Tags               []Tag             `json:"Tags,omitempty"`
+	KeyValueList       []string          `json:"KeyValueList,omitempty"`
+	RobotValues        []AssetRobotValue `json:"RobotValues,omitempty"`
+	UserValues         []AssetUserValue  `json:"UserValues,omitempty"`
+}
+
+// AssetValue struct defines the value part shared by the per-robot and per-user asset values
+type AssetValue struct {
+	ValueType          string `json:"ValueType"`
+	Value              string `json:"Value,omitempty"`
+	StringValue        string `json:"StringValue,omitempty"`
+	BoolValue          bool   `json:"BoolValue,omitempty"`
+	IntValue           int    `json:"IntValue,omitempty"`
+	CredentialUsername string `json:"CredentialUsername,omitempty"`
+	CredentialPassword string `json:"CredentialPassword,omitempty"`
+	SecretValue        string `json:"SecretValue,omitempty"`
+	ExternalName       string `json:"ExternalName,omitempty"`
+	CredentialStoreId  int    `json:"CredentialStoreId,omitempty"`
+}
+
+// AssetRobotValue struct defines the value an asset has for a robot
+type AssetRobotValue struct {
+	RobotID   uint   `json:"RobotId"`
+	RobotName string `json:"RobotName,omitempty"`
+	KeyTrail  string `json:"KeyTrail,omitempty"`
+	AssetValue
+}
+
+// AssetUserValue struct defines the value an asset has for a user, optionally on a machine
+type AssetUserValue struct {
+	UserID      uint   `json:"UserId"`
+	UserName    string `json:"UserName,omitempty"`
+	MachineID   uint   `json:"MachineId,omitempty"`
+	MachineName string `json:"MachineName,omitempty"`
+	KeyTrail    string `json:"KeyTrail,omitempty"`
+	AssetValue
 }
 
 // AssetList struct defines what the asset list looks like
@@ -52,14 +96,183 @@ type AssetList struct {
 	Value []Asset `json:"value"`
 }
 
+// AssetShareRequest defines how the request looks like when sharing assets to folders
+type AssetShareRequest struct {
+	AssetIDs          []uint `json:"assetIds"`
+	ToAddFolderIDs    []uint `json:"toAddFolderIds"`
+	ToRemoveFolderIDs []uint `json:"toRemoveFolderIds"`
+}
+
+// AssetFolders struct defines the folders an asset is shared to
+type AssetFolders struct {
+	AccessibleFolders []Folder `json:"AccessibleFolders"`
+	TotalFoldersCount int      `json:"TotalFoldersCount"`
+}
+
 // Tag struct defines what the tag item looks like
 type Tag struct {
 	Name         string `json:"Name"`
 	DisplayName  string `json:"DisplayName"`
-	Value        string `json:"Value"`
+	Value        string `json:"Value,omitempty"`
 	DisplayValue string `json:"DisplayValue,omitempty"`
 }
 
+type assetAlias Asset
+type assetRobotValueAlias AssetRobotValue
+type assetUserValueAlias AssetUserValue
+
+// String prints the asset without its credential password or secret values
+func (a Asset) String() string {
+	alias := assetAlias(a)
+	alias.CredentialPassword = redact(alias.CredentialPassword)
+	alias.SecretValue = redact(alias.SecretValue)
+
+	if _, ok := sensitiveValueKeys[alias.ValueType]; ok {
+		alias.StringValue = redact(alias.StringValue)
+		alias.Value = redact(alias.Value)
+	}
+
+	return fmt.Sprintf("%+v", alias)
+}
+
+// String prints the robot value without its credential password or secret values
+func (v AssetRobotValue) String() string {
+	alias := assetRobotValueAlias(v)
+	alias.CredentialPassword = redact(alias.CredentialPassword)
+	alias.SecretValue = redact(alias.SecretValue)
+
+	if _, ok := sensitiveValueKeys[alias.ValueType]; ok {
+		alias.StringValue = redact(alias.StringValue)
+		alias.Value = redact(alias.Value)
+	}
+
+	return fmt.Sprintf("%+v", alias)
+}
+
+// String prints the user value without its credential password or secret values
+func (v AssetUserValue) String() string {
+	alias := assetUserValueAlias(v)
+	alias.CredentialPassword = redact(alias.CredentialPassword)
+	alias.SecretValue = redact(alias.SecretValue)
+
+	if _, ok := sensitiveValueKeys[alias.ValueType]; ok {
+		alias.StringValue = redact(alias.StringValue)
+		alias.Value = redact(alias.Value)
+	}
+
+	return fmt.Sprintf("%+v", alias)
+}
+
+// NewTextAsset creates a global asset holding a text value
+func NewTextAsset(name string, value string) Asset {
+	return Asset{Name: name, ValueScope: ValueScopeGlobal, ValueType: ValueTypeText, StringValue: value}
+}
+
+// NewIntegerAsset creates a global asset holding an integer value
+func NewIntegerAsset(name string, value int) Asset {
+	return Asset{Name: name, ValueScope: ValueScopeGlobal, ValueType: ValueTypeInteger, IntValue: value}
+}
+
+// NewBoolAsset creates a global asset holding a boolean value
+func NewBoolAsset(name string, value bool) Asset {
+	return Asset{Name: name, ValueScope: ValueScopeGlobal, ValueType: ValueTypeBool, BoolValue: value}
+}
+
+// NewCredentialAsset creates a global asset holding a username and password
+func NewCredentialAsset(name string, username string, password string) Asset {
+	return Asset{Name: name, ValueScope: ValueScopeGlobal, ValueType: ValueTypeCredential, CredentialUsername: username, CredentialPassword: password}
+}
+
+// NewSecretAsset creates a global asset holding a secret value
+func NewSecretAsset(name string, value string) Asset {
+	return Asset{Name: name, ValueScope: ValueScopeGlobal, ValueType: ValueTypeSecret, SecretValue: value}
+}
+
+// NewDBConnectionStringAsset creates a global asset holding a database connection string
+func NewDBConnectionStringAsset(name string, value string) Asset {
+	return Asset{Name: name, ValueScope: ValueScopeGlobal, ValueType: ValueTypeDBConnectionString, StringValue: value}
+}
+
+// Text returns the value of a text asset
+func (a Asset) Text() (string, error) {
+	return a.StringValue, a.checkValueType(ValueTypeText)
+}
+
+// Integer returns the value of an integer asset
+func (a Asset) Integer() (int, error) {
+	return a.IntValue, a.checkValueType(ValueTypeInteger)
+}
+
+// Bool returns the value of a boolean asset
+func (a Asset) Bool() (bool, error) {
+	return a.BoolValue, a.checkValueType(ValueTypeBool)
+}
+
+// Credential returns the username and password of a credential asset
+func (a Asset) Credential() (string, string, error) {
+	return a.CredentialUsername, a.CredentialPassword, a.checkValueType(ValueTypeCredential)
+}
+
+// Secret returns the value of a secret asset
+func (a Asset) Secret() (string, error) {
+	return a.SecretValue, a.checkValueType(ValueTypeSecret)
+}
+
+// DBConnectionString returns the value of a database connection string asset
+func (a Asset) DBConnectionString() (string, error) {
+	return a.StringValue, a.checkValueType(ValueTypeDBConnectionString)
+}
+
+func (a Asset) checkValueType(valueType string) error {
+	if a.ValueType != valueType {
+		return fmt.Errorf("%w: asset %q is %s, not %s", ErrAssetValueType, a.Name, a.ValueType, valueType)
+	}
+
+	return nil
+}
+
+// MarshalJSON always sends the typed value of the asset so that false, 0 and "" can be stored, and
+// the loaded values of per-robot assets even when empty so that removing the last value clears it.
+// Nil values were not loaded and are left out so the values on the orchestrator are kept.
+func (a Asset) MarshalJSON() ([]byte, error) {
+	fields := typedValueFields(a.ValueType, a.StringValue, a.BoolValue, a.IntValue)
+
+	if a.ValueScope == ValueScopePerRobot {
+		if a.RobotValues != nil {
+			fields["RobotValues"] = a.RobotValues
+		}
+
+		if a.UserValues != nil {
+			fields["UserValues"] = a.UserValues
+		}
+	}
+
+	return marshalWithFields(assetAlias(a), fields)
+}
+
+// MarshalJSON always sends the typed value so that false, 0 and "" can be stored
+func (v AssetRobotValue) MarshalJSON() ([]byte, error) {
+	return marshalWithFields(assetRobotValueAlias(v), typedValueFields(v.ValueType, v.StringValue, v.BoolValue, v.IntValue))
+}
+
+// MarshalJSON always sends the typed value so that false, 0 and "" can be stored
+func (v AssetUserValue) MarshalJSON() ([]byte, error) {
+	return marshalWithFields(assetUserValueAlias(v), typedValueFields(v.ValueType, v.StringValue, v.BoolValue, v.IntValue))
+}
+
+func typedValueFields(valueType string, stringValue string, boolValue bool, intValue int) map[string]interface{} {
+	switch valueType {
+	case ValueTypeText, ValueTypeDBConnectionString:
+		return map[string]interface{}{"StringValue": stringValue}
+	case ValueTypeBool:
+		return map[string]interface{}{"BoolValue": boolValue}
+	case ValueTypeInteger:
+		return map[string]interface{}{"IntValue": intValue}
+	}
+
+	return map[string]interface{}{}
+}
+
 // GetByID fetches the asset by id
 func (a *AssetHandler) GetByID(ID uint) (Asset, error) {
 	var asset Asset
@@ -82,7 +295,7 @@ func (a *AssetHandler) GetByName(name string) (Asset, error) {
 	var asset Asset
 
 	params := url.Values{}
-	params.Set("$filter", fmt.Sprintf("Name eq '%s'", name))
+	params.Set("$filter", fmt.Sprintf("Name eq %s", odataLiteral(name)))
 
 	url := fmt.Sprintf("%s%s?%s", a.Client.BaseURL, AssetEndpoint, params.Encode())
 
@@ -118,6 +331,32 @@ func (a *AssetHandler) List(filters map[string]string) ([]Asset, int, error) {
 	return assetList.Value, assetList.Count, err
 }
 
+// ListAll fetches every asset matching the query parameters, following the pages
+func (a *AssetHandler) ListAll(filters map[string]string) ([]Asset, error) {
+	var assets []Asset
+
+	params := map[string]string{}
+	for k, v := range filters {
+		params[k] = v
+	}
+
+	for {
+		params["$top"] = strconv.Itoa(listPageSize)
+		params["$skip"] = strconv.Itoa(len(assets))
+
+		page, _, err := a.List(params)
+		if err != nil {
+			return assets, err
+		}
+
+		assets = append(assets, page...)
+
+		if len(page) < listPageSize {
+			return assets, nil
+		}
+	}
+}
+
 // Store creates and saves an asset on the orchestrator
 func (a *AssetHandler) Store(asset Asset) (Asset, error) {
 	var result Asset
@@ -146,6 +385,22 @@ func (a *AssetHandler) Update(asset Asset) (Asset, error) {
 	return a.GetByID(asset.ID)
 }
 
+// Upsert creates the asset or updates the existing asset with the same name
+func (a *AssetHandler) Upsert(asset Asset) (Asset, error) {
+	existing, err := a.GetByName(asset.Name)
+	if err != nil {
+		return asset, err
+	}
+
+	if existing.ID == 0 {
+		return a.Store(asset)
+	}
+
+	asset.ID = existing.ID
+
+	return a.Update(asset)
+}
+
 // DeleteByID deletes an asset by id
 func (a *AssetHandler) DeleteByID(ID uint) error {
 	url := fmt.Sprintf("%s%s(%d)", a.Client.BaseURL, AssetEndpoint, ID)
@@ -155,6 +410,147 @@ func (a *AssetHandler) DeleteByID(ID uint) error {
 	return err
 }
 
+// ShareToFolders links the assets to the folders, the assets must already be accessible from the handler folder
+func (a *AssetHandler) ShareToFolders(assetIDs []uint, folderIDs []uint) error {
+	return a.shareToFolders(AssetShareRequest{AssetIDs: assetIDs, ToAddFolderIDs: folderIDs, ToRemoveFolderIDs: []uint{}})
+}
+
+// RemoveFromFolders unlinks the assets from the folders
+func (a *AssetHandler) RemoveFromFolders(assetIDs []uint, folderIDs []uint) error {
+	return a.shareToFolders(AssetShareRequest{AssetIDs: assetIDs, ToAddFolderIDs: []uint{}, ToRemoveFolderIDs: folderIDs})
+}
+
+// GetFoldersForAsset fetches the folders the asset is shared to that the user can access
+func (a *AssetHandler) GetFoldersForAsset(ID uint) ([]Folder, int, error) {
+	var assetFolders AssetFolders
+
+	url := fmt.Sprintf("%s%s(id=%d)", a.Client.BaseURL, AssetGetFoldersForAssetEndpoint, ID)
+
+	resp, err := a.Client.SendWithAuthorization("GET", url, nil, a.buildHeaders(), map[string]string{})
+	if err != nil {
+		return assetFolders.AccessibleFolders, assetFolders.TotalFoldersCount, err
+	}
+
+	err = json.Unmarshal(resp, &assetFolders)
+
+	return assetFolders.AccessibleFolders, assetFolders.TotalFoldersCount, err
+}
+
+func (a *AssetHandler) shareToFolders(request AssetShareRequest) error {
+	url := fmt.Sprintf("%s%s", a.Client.BaseURL, AssetShareToFoldersEndpoint)
+
+	_, err := a.Client.SendWithAuthorization("POST", url, request, a.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// SetRobotValue adds or replaces the value an asset has for a robot
+func (a *AssetHandler) SetRobotValue(assetID uint, value AssetRobotValue) (Asset, error) {
+	asset, err := a.GetByID(assetID)
+	if err != nil {
+		return asset, err
+	}
+
+	asset.ValueScope = ValueScopePerRobot
+	asset.RobotValues = append(removeRobotValue(asset.RobotValues, value.RobotID), value)
+
+	return a.Update(asset)
+}
+
+// RemoveRobotValue removes the value an asset has for a robot
+func (a *AssetHandler) RemoveRobotValue(assetID uint, robotID uint) (Asset, error) {
+	asset, err := a.GetByID(assetID)
+	if err != nil {
+		return asset, err
+	}
+
+	asset.RobotValues = removeRobotValue(asset.RobotValues, robotID)
+
+	return a.Update(asset)
+}
+
+// SetUserValue adds or replaces the value an asset has for a user and machine pair
+func (a *AssetHandler) SetUserValue(assetID uint, value AssetUserValue) (Asset, error) {
+	asset, err := a.GetByID(assetID)
+	if err != nil {
+		return asset, err
+	}
+
+	asset.ValueScope = ValueScopePerRobot
+	asset.UserValues = append(removeUserValue(asset.UserValues, value.UserID, value.MachineID), value)
+
+	return a.Update(asset)
+}
+
+// RemoveUserValue removes the value an asset has for a user and machine pair
+func (a *AssetHandler) RemoveUserValue(assetID uint, userID uint, machineID uint) (Asset, error) {
+	asset, err := a.GetByID(assetID)
+	if err != nil {
+		return asset, err
+	}
+
+	asset.UserValues = removeUserValue(asset.UserValues, userID, machineID)
+
+	return a.Update(asset)
+}
+
+// removeRobotValue keeps nil values nil, they were not loaded so there is nothing to remove
+func removeRobotValue(values []AssetRobotValue, robotID uint) []AssetRobotValue {
+	if values == nil {
+		return nil
+	}
+
+	result := []AssetRobotValue{}
+
+	for _, v := range values {
+		if v.RobotID != robotID {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+func removeUserValue(values []AssetUserValue, userID uint, machineID uint) []AssetUserValue {
+	if values == nil {
+		return nil
+	}
+
+	result := []AssetUserValue{}
+
+	for _, v := range values {
+		if v.UserID != userID || v.MachineID != machineID {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// marshalWithFields marshals v and then sets the given fields, bypassing their omitempty tags
+func marshalWithFields(v interface{}, fields map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(fields) == 0 {
+		return data, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return data, err
+	}
+
+	for k, field := range fields {
+		value, err := json.Marshal(field)
+		if err != nil {
+			return data, err
+		}
+
+		object[k] = value
+	}
+
+	return json.Marshal(object)
+}
+
 func (a *AssetHandler) buildHeaders() map[string]string {
 	var headers = map[string]string{}
 
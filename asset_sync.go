@@ -0,0 +1,490 @@
+package uipath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	AssetChangeCreate    = "create"
+	AssetChangeUpdate    = "update"
+	AssetChangeDelete    = "delete"
+	AssetChangeUnchanged = "unchanged"
+)
+
+// AssetManifest defines the desired state of the assets of one or more folders
+type AssetManifest struct {
+	FolderID uint        `json:"folderId" yaml:"folderId"`
+	Assets   []AssetSpec `json:"assets" yaml:"assets"`
+}
+
+// AssetSpec defines the desired state of a single asset
+type AssetSpec struct {
+	Name            string      `json:"name" yaml:"name"`
+	Type            string      `json:"type" yaml:"type"`
+	Value           interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	ValueFromEnv    string      `json:"valueFromEnv,omitempty" yaml:"valueFromEnv,omitempty"`
+	Username        string      `json:"username,omitempty" yaml:"username,omitempty"`
+	Password        string      `json:"password,omitempty" yaml:"password,omitempty"`
+	PasswordFromEnv string      `json:"passwordFromEnv,omitempty" yaml:"passwordFromEnv,omitempty"`
+	Description     string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags            []string    `json:"tags,omitempty" yaml:"tags,omitempty"`
+	FolderID        uint        `json:"folderId,omitempty" yaml:"folderId,omitempty"`
+}
+
+// AssetChange defines a single change needed to reach the desired state
+type AssetChange struct {
+	Type     string
+	Name     string
+	FolderID uint
+	Fields   []string
+	Desired  Asset
+	Current  Asset
+}
+
+// AssetSyncPlan defines the changes needed to reach the desired state
+type AssetSyncPlan struct {
+	Changes []AssetChange
+}
+
+// AssetSyncFailure defines a change that could not be applied
+type AssetSyncFailure struct {
+	Change AssetChange
+	Err    error
+}
+
+// AssetSyncReport summarizes the outcome of a sync
+type AssetSyncReport struct {
+	DryRun    bool
+	Plan      AssetSyncPlan
+	Created   int
+	Updated   int
+	Deleted   int
+	Unchanged int
+	Failures  []AssetSyncFailure
+}
+
+// AssetSyncer reconciles the assets on the orchestrator with a manifest
+type AssetSyncer struct {
+	Client *Client
+
+	// DryRun only computes the plan without applying it
+	DryRun bool
+
+	// Prune deletes the assets of the manifest folders that are not in the manifest
+	Prune bool
+
+	// UpdateSecrets always updates credential and secret assets since their values cannot be read back
+	UpdateSecrets bool
+}
+
+// LoadAssetManifest reads a manifest from a yaml or json file
+func LoadAssetManifest(path string) (AssetManifest, error) {
+	var manifest AssetManifest
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return manifest, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &manifest)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &manifest)
+	default:
+		err = fmt.Errorf("unsupported manifest format: %s", path)
+	}
+
+	return manifest, err
+}
+
+// Asset builds the asset described by the spec
+func (s AssetSpec) Asset() (Asset, error) {
+	var asset Asset
+
+	value := s.Value
+	if s.ValueFromEnv != "" {
+		value = os.Getenv(s.ValueFromEnv)
+	}
+
+	password := s.Password
+	if s.PasswordFromEnv != "" {
+		password = os.Getenv(s.PasswordFromEnv)
+	}
+
+	switch s.Type {
+	case ValueTypeText:
+		asset = NewTextAsset(s.Name, specString(value))
+	case ValueTypeDBConnectionString:
+		asset = NewDBConnectionStringAsset(s.Name, specString(value))
+	case ValueTypeSecret:
+		asset = NewSecretAsset(s.Name, specString(value))
+	case ValueTypeCredential:
+		asset = NewCredentialAsset(s.Name, s.Username, password)
+	case ValueTypeInteger:
+		i, err := specInt(value)
+		if err != nil {
+			return asset, fmt.Errorf("asset %q: %w", s.Name, err)
+		}
+
+		asset = NewIntegerAsset(s.Name, i)
+	case ValueTypeBool:
+		b, err := specBool(value)
+		if err != nil {
+			return asset, fmt.Errorf("asset %q: %w", s.Name, err)
+		}
+
+		asset = NewBoolAsset(s.Name, b)
+	default:
+		return asset, fmt.Errorf("asset %q: unsupported value type %q", s.Name, s.Type)
+	}
+
+	asset.Description = s.Description
+	for _, name := range s.Tags {
+		asset.Tags = append(asset.Tags, Tag{Name: name, DisplayName: name})
+	}
+
+	return asset, nil
+}
+
+// Sync computes the plan for the manifest and applies it unless running dry
+func (s *AssetSyncer) Sync(manifest AssetManifest) (AssetSyncReport, error) {
+	plan, err := s.Plan(manifest)
+	if err != nil {
+		return AssetSyncReport{Plan: plan}, err
+	}
+
+	if s.DryRun {
+		report := AssetSyncReport{DryRun: true, Plan: plan}
+		for _, change := range plan.Changes {
+			report.count(change)
+		}
+
+		return report, nil
+	}
+
+	return s.Apply(plan)
+}
+
+// Plan computes the changes needed to reach the state described by the manifest
+func (s *AssetSyncer) Plan(manifest AssetManifest) (AssetSyncPlan, error) {
+	var plan AssetSyncPlan
+
+	desiredByFolder := map[uint]map[string]Asset{}
+	for _, spec := range manifest.Assets {
+		if spec.Name == "" {
+			return plan, errors.New("asset without a name in manifest")
+		}
+
+		folderID := spec.FolderID
+		if folderID == 0 {
+			folderID = manifest.FolderID
+		}
+
+		asset, err := spec.Asset()
+		if err != nil {
+			return plan, err
+		}
+
+		if desiredByFolder[folderID] == nil {
+			desiredByFolder[folderID] = map[string]Asset{}
+		}
+
+		if _, ok := desiredByFolder[folderID][asset.Name]; ok {
+			return plan, fmt.Errorf("asset %q is defined twice for folder %d", asset.Name, folderID)
+		}
+
+		desiredByFolder[folderID][asset.Name] = asset
+	}
+
+	for _, folderID := range sortedFolderIDs(desiredByFolder) {
+		handler := AssetHandler{Client: s.Client, FolderId: folderID}
+
+		current, err := handler.ListAll(map[string]string{})
+		if err != nil {
+			return plan, err
+		}
+
+		currentByName := map[string]Asset{}
+		for _, asset := range current {
+			currentByName[asset.Name] = asset
+		}
+
+		desired := desiredByFolder[folderID]
+		for _, name := range sortedAssetNames(desired) {
+			plan.Changes = append(plan.Changes, s.diff(folderID, desired[name], currentByName[name]))
+		}
+
+		if !s.Prune {
+			continue
+		}
+
+		for _, asset := range current {
+			if _, ok := desired[asset.Name]; !ok {
+				plan.Changes = append(plan.Changes, AssetChange{Type: AssetChangeDelete, Name: asset.Name, FolderID: folderID, Current: asset})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply applies the changes of the plan, carrying on after a failed change
+func (s *AssetSyncer) Apply(plan AssetSyncPlan) (AssetSyncReport, error) {
+	report := AssetSyncReport{Plan: plan}
+
+	for _, change := range plan.Changes {
+		handler := AssetHandler{Client: s.Client, FolderId: change.FolderID}
+
+		var err error
+		switch change.Type {
+		case AssetChangeCreate:
+			_, err = handler.Store(change.Desired)
+		case AssetChangeUpdate:
+			_, err = s.update(handler, change)
+		case AssetChangeDelete:
+			err = handler.DeleteByID(change.Current.ID)
+		}
+
+		if err != nil {
+			report.Failures = append(report.Failures, AssetSyncFailure{Change: change, Err: err})
+			continue
+		}
+
+		report.count(change)
+	}
+
+	if len(report.Failures) > 0 {
+		return report, fmt.Errorf("%d asset changes failed, first error: %w", len(report.Failures), report.Failures[0].Err)
+	}
+
+	return report, nil
+}
+
+// update updates the asset keeping the scope and the robot and user values of a per-robot asset, the
+// manifest only describes the value of an asset, which is the default value of a per-robot asset
+func (s *AssetSyncer) update(handler AssetHandler, change AssetChange) (Asset, error) {
+	desired := change.Desired
+
+	if change.Current.ValueScope == ValueScopePerRobot {
+		current, err := handler.GetByID(change.Current.ID)
+		if err != nil {
+			return desired, err
+		}
+
+		desired.ValueScope = current.ValueScope
+		desired.HasDefaultValue = current.HasDefaultValue
+		desired.RobotValues = current.RobotValues
+		desired.UserValues = current.UserValues
+	}
+
+	return handler.Update(desired)
+}
+
+func (s *AssetSyncer) diff(folderID uint, desired Asset, current Asset) AssetChange {
+	change := AssetChange{Name: desired.Name, FolderID: folderID, Desired: desired, Current: current}
+
+	if current.ID == 0 {
+		change.Type = AssetChangeCreate
+		return change
+	}
+
+	change.Desired.ID = current.ID
+
+	if desired.ValueType != current.ValueType {
+		change.Fields = append(change.Fields, "ValueType")
+	}
+
+	switch desired.ValueType {
+	case ValueTypeText, ValueTypeDBConnectionString:
+		if desired.StringValue != current.StringValue {
+			change.Fields = append(change.Fields, "StringValue")
+		}
+	case ValueTypeInteger:
+		if desired.IntValue != current.IntValue {
+			change.Fields = append(change.Fields, "IntValue")
+		}
+	case ValueTypeBool:
+		if desired.BoolValue != current.BoolValue {
+			change.Fields = append(change.Fields, "BoolValue")
+		}
+	case ValueTypeCredential:
+		if desired.CredentialUsername != current.CredentialUsername {
+			change.Fields = append(change.Fields, "CredentialUsername")
+		}
+
+		if s.UpdateSecrets {
+			change.Fields = append(change.Fields, "CredentialPassword")
+		}
+	case ValueTypeSecret:
+		if s.UpdateSecrets {
+			change.Fields = append(change.Fields, "SecretValue")
+		}
+	}
+
+	if desired.Description != current.Description {
+		change.Fields = append(change.Fields, "Description")
+	}
+
+	if !sameTags(tagNames(desired.Tags), tagNames(current.Tags)) {
+		change.Fields = append(change.Fields, "Tags")
+	}
+
+	change.Type = AssetChangeUnchanged
+	if len(change.Fields) > 0 {
+		change.Type = AssetChangeUpdate
+	}
+
+	return change
+}
+
+// String prints the plan the way a dry run shows it
+func (p AssetSyncPlan) String() string {
+	var b strings.Builder
+
+	for _, change := range p.Changes {
+		switch change.Type {
+		case AssetChangeCreate:
+			fmt.Fprintf(&b, "+ create %s (folder %d)\n", change.Name, change.FolderID)
+		case AssetChangeUpdate:
+			fmt.Fprintf(&b, "~ update %s (folder %d): %s\n", change.Name, change.FolderID, strings.Join(change.Fields, ", "))
+		case AssetChangeDelete:
+			fmt.Fprintf(&b, "- delete %s (folder %d)\n", change.Name, change.FolderID)
+		}
+	}
+
+	return b.String()
+}
+
+// String prints the summary of the sync
+func (r AssetSyncReport) String() string {
+	prefix := ""
+	if r.DryRun {
+		prefix = "dry run: "
+	}
+
+	summary := fmt.Sprintf("%s%d created, %d updated, %d deleted, %d unchanged, %d failed", prefix, r.Created, r.Updated, r.Deleted, r.Unchanged, len(r.Failures))
+
+	for _, failure := range r.Failures {
+		summary += fmt.Sprintf("\n! %s %s (folder %d): %s", failure.Change.Type, failure.Change.Name, failure.Change.FolderID, failure.Err)
+	}
+
+	return summary
+}
+
+func (r *AssetSyncReport) count(change AssetChange) {
+	switch change.Type {
+	case AssetChangeCreate:
+		r.Created++
+	case AssetChangeUpdate:
+		r.Updated++
+	case AssetChangeDelete:
+		r.Deleted++
+	case AssetChangeUnchanged:
+		r.Unchanged++
+	}
+}
+
+func specString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(value)
+}
+
+func specInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	case nil:
+		return 0, nil
+	}
+
+	return 0, fmt.Errorf("%v is not an integer", value)
+}
+
+func specBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	case nil:
+		return false, nil
+	}
+
+	return false, fmt.Errorf("%v is not a boolean", value)
+}
+
+func sameTags(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func tagNames(tags []Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
+
+func sortedFolderIDs(m map[uint]map[string]Asset) []uint {
+	ids := []uint{}
+	for id := range m {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+func sortedAssetNames(m map[string]Asset) []string {
+	names := []string{}
+	for name := range m {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
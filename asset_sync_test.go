@@ -0,0 +1,130 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAssetManifest = `
+folderId: 1
+assets:
+  - name: ApiUrl
+    type: Text
+    value: https://example.com
+    description: Api endpoint
+  - name: RetryCount
+    type: Integer
+    value: 0
+  - name: Enabled
+    type: Bool
+    value: false
+    tags: [ops]
+`
+
+func (suite *AssetTestSuite) prepareSyncResponders() {
+	current := AssetList{
+		Count: 3,
+		Value: []Asset{
+			{ID: 1, Name: "ApiUrl", ValueType: ValueTypeText, StringValue: "https://example.com", Description: "Api endpoint"},
+			{ID: 2, Name: "RetryCount", ValueType: ValueTypeInteger, IntValue: 3},
+			{ID: 3, Name: "Legacy", ValueType: ValueTypeText, StringValue: "old"},
+		},
+	}
+
+	httpmock.RegisterResponder("GET", testBaseURL+AssetEndpoint, httpmock.NewJsonResponderOrPanic(200, current))
+}
+
+func (suite *AssetTestSuite) loadManifest() AssetManifest {
+	path := filepath.Join(suite.T().TempDir(), "assets.yaml")
+	assert.Nil(suite.T(), os.WriteFile(path, []byte(testAssetManifest), 0600))
+
+	manifest, err := LoadAssetManifest(path)
+	assert.Nil(suite.T(), err)
+
+	return manifest
+}
+
+func (suite *AssetTestSuite) TestSyncDryRun() {
+	suite.prepareSyncResponders()
+
+	syncer := AssetSyncer{Client: suite.c, DryRun: true, Prune: true}
+
+	report, err := syncer.Sync(suite.loadManifest())
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "+ create Enabled (folder 1)\n~ update RetryCount (folder 1): IntValue\n- delete Legacy (folder 1)\n", report.Plan.String())
+	assert.Equal(suite.T(), "dry run: 1 created, 1 updated, 1 deleted, 1 unchanged, 0 failed", report.String())
+	assert.Equal(suite.T(), 1, httpmock.GetTotalCallCount())
+}
+
+func (suite *AssetTestSuite) TestSyncApply() {
+	suite.prepareSyncResponders()
+
+	httpmock.RegisterResponder("POST", testBaseURL+AssetEndpoint, httpmock.NewJsonResponderOrPanic(201, Asset{ID: 4, Name: "Enabled"}))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s%s(2)", testBaseURL, AssetEndpoint), httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s%s(2)", testBaseURL, AssetEndpoint), httpmock.NewJsonResponderOrPanic(200, Asset{ID: 2, Name: "RetryCount"}))
+
+	syncer := AssetSyncer{Client: suite.c}
+
+	report, err := syncer.Sync(suite.loadManifest())
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "1 created, 1 updated, 0 deleted, 1 unchanged, 0 failed", report.String())
+}
+
+func (suite *AssetTestSuite) TestSyncKeepsPerRobotValues() {
+	var sent Asset
+
+	perRobot := Asset{
+		ID:          1,
+		Name:        "ApiUrl",
+		ValueScope:  ValueScopePerRobot,
+		ValueType:   ValueTypeText,
+		StringValue: "https://old.example.com",
+		RobotValues: []AssetRobotValue{
+			{RobotID: 3, AssetValue: AssetValue{ValueType: ValueTypeText, StringValue: "https://robot3.example.com"}},
+		},
+	}
+
+	httpmock.RegisterResponder("GET", testBaseURL+AssetEndpoint, httpmock.NewJsonResponderOrPanic(200, AssetList{Count: 1, Value: []Asset{{ID: 1, Name: "ApiUrl", ValueScope: ValueScopePerRobot, ValueType: ValueTypeText, StringValue: "https://old.example.com"}}}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s%s(1)", testBaseURL, AssetEndpoint), httpmock.NewJsonResponderOrPanic(200, perRobot))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s%s(1)", testBaseURL, AssetEndpoint), func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+
+	syncer := AssetSyncer{Client: suite.c}
+
+	report, err := syncer.Sync(AssetManifest{FolderID: 1, Assets: []AssetSpec{{Name: "ApiUrl", Type: ValueTypeText, Value: "https://example.com"}}})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "0 created, 1 updated, 0 deleted, 0 unchanged, 0 failed", report.String())
+	assert.Equal(suite.T(), ValueScopePerRobot, sent.ValueScope)
+	assert.Equal(suite.T(), "https://example.com", sent.StringValue)
+	assert.Equal(suite.T(), perRobot.RobotValues, sent.RobotValues)
+}
+
+func (suite *AssetTestSuite) TestSyncMatchingTagsAreUnchanged() {
+	current := `{"@odata.count":1,"value":[{"Id":3,"Name":"Enabled","ValueScope":"Global","ValueType":"Bool","BoolValue":false,"Tags":[{"Name":"ops","DisplayName":"ops"}]}]}`
+
+	httpmock.RegisterResponder("GET", testBaseURL+AssetEndpoint, httpmock.NewStringResponder(200, current))
+
+	syncer := AssetSyncer{Client: suite.c}
+
+	report, err := syncer.Sync(AssetManifest{FolderID: 1, Assets: []AssetSpec{{Name: "Enabled", Type: ValueTypeBool, Value: false, Tags: []string{"ops"}}}})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "0 created, 0 updated, 0 deleted, 1 unchanged, 0 failed", report.String())
+	assert.Equal(suite.T(), 1, httpmock.GetTotalCallCount())
+
+	data, err := json.Marshal(report.Plan.Changes[0].Desired)
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), string(data), `"Tags":[{"Name":"ops","DisplayName":"ops"}]`)
+}
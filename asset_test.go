@@ -0,0 +1,196 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/comvex-jp/uipath-go/configs"
+	"github.com/jarcoal/httpmock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const testBaseURL = "https://cloud.uipath.com/exampleOrg/exampleTenant/orchestrator_/odata/"
+
+type AssetTestSuite struct {
+	suite.Suite
+	c *Client
+	h *AssetHandler
+}
+
+func (suite *AssetTestSuite) SetupTest() {
+	suite.c = &Client{
+		HttpClient: &http.Client{Transport: httpmock.DefaultTransport},
+		BaseURL:    testBaseURL,
+		Cache:      cache.New(5*time.Minute, 10*time.Minute),
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 5*time.Minute)
+
+	suite.h = &AssetHandler{Client: suite.c, FolderId: 1}
+
+	httpmock.Activate()
+}
+
+func (suite *AssetTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	suite.c.Cache.Flush()
+}
+
+func TestAsset(t *testing.T) {
+	suite.Run(t, new(AssetTestSuite))
+}
+
+func (suite *AssetTestSuite) TestStringRedactsCredentialPasswords() {
+	asset := Asset{
+		Name:               "login",
+		ValueType:          ValueTypeCredential,
+		CredentialUsername: "robot",
+		CredentialPassword: "hunter2",
+		RobotValues: []AssetRobotValue{
+			{RobotID: 1, AssetValue: AssetValue{ValueType: ValueTypeCredential, CredentialPassword: "hunter3"}},
+		},
+	}
+
+	printed := fmt.Sprint(asset)
+
+	assert.NotContains(suite.T(), printed, "hunter2")
+	assert.NotContains(suite.T(), printed, "hunter3")
+	assert.Contains(suite.T(), printed, "robot")
+	assert.Contains(suite.T(), printed, RedactedValue)
+
+	printed = fmt.Sprint(NewDBConnectionStringAsset("warehouse", "Server=db;Password=hunter4"))
+
+	assert.NotContains(suite.T(), printed, "hunter4")
+	assert.Contains(suite.T(), printed, "warehouse")
+}
+
+func (suite *AssetTestSuite) TestRemoveLastRobotValueSendsEmptyList() {
+	var sent map[string]interface{}
+
+	asset := Asset{
+		ID:         7,
+		Name:       "perRobot",
+		ValueScope: ValueScopePerRobot,
+		ValueType:  ValueTypeText,
+		RobotValues: []AssetRobotValue{
+			{RobotID: 3, AssetValue: AssetValue{ValueType: ValueTypeText, StringValue: "robot3"}},
+		},
+	}
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s%s(7)", testBaseURL, AssetEndpoint), httpmock.NewJsonResponderOrPanic(200, asset))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s%s(7)", testBaseURL, AssetEndpoint), func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(body, &sent)
+
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+
+	_, err := suite.h.RemoveRobotValue(7, 3)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []interface{}{}, sent["RobotValues"])
+}
+
+func (suite *AssetTestSuite) TestUpdateKeepsRobotValuesNotLoaded() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s%s(7)", testBaseURL, AssetEndpoint), httpmock.NewStringResponder(200, `{"Id":7,"Name":"perRobot","ValueScope":"PerRobot","ValueType":"Text","StringValue":"default"}`))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s%s(7)", testBaseURL, AssetEndpoint), func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(body, &sent)
+
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+
+	asset, err := suite.h.GetByID(7)
+	assert.Nil(suite.T(), err)
+
+	asset.StringValue = "changed"
+
+	_, err = suite.h.Update(asset)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "changed", sent["StringValue"])
+	assert.NotContains(suite.T(), sent, "RobotValues")
+	assert.NotContains(suite.T(), sent, "UserValues")
+}
+
+func (suite *AssetTestSuite) TestTypedGetters() {
+	enabled, err := NewBoolAsset("enabled", true).Bool()
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), enabled)
+
+	_, err = NewTextAsset("name", "value").Integer()
+	assert.ErrorIs(suite.T(), err, ErrAssetValueType)
+
+	username, password, err := NewCredentialAsset("login", "robot", "hunter2").Credential()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "robot", username)
+	assert.Equal(suite.T(), "hunter2", password)
+
+	_, err = NewSecretAsset("token", "s3cr3t").Text()
+	assert.ErrorIs(suite.T(), err, ErrAssetValueType)
+}
+
+func (suite *AssetTestSuite) TestZeroValuesRoundTrip() {
+	for _, asset := range []Asset{NewBoolAsset("flag", false), NewIntegerAsset("count", 0), NewTextAsset("text", "")} {
+		data, err := json.Marshal(asset)
+		assert.Nil(suite.T(), err)
+
+		var fields map[string]interface{}
+		json.Unmarshal(data, &fields)
+
+		switch asset.ValueType {
+		case ValueTypeBool:
+			assert.Equal(suite.T(), false, fields["BoolValue"])
+		case ValueTypeInteger:
+			assert.Equal(suite.T(), float64(0), fields["IntValue"])
+		case ValueTypeText:
+			assert.Equal(suite.T(), "", fields["StringValue"])
+		}
+
+		var decoded Asset
+		assert.Nil(suite.T(), json.Unmarshal(data, &decoded))
+		assert.Equal(suite.T(), asset, decoded)
+	}
+}
+
+func (suite *AssetTestSuite) TestGetRobotAssetByRobotKey() {
+	resp := `{"Id":3,"Name":"login","ValueType":"Credential","CredentialUsername":"robot","CredentialPassword":"hunter2","KeyValueList":[{"Key":"region","Value":"jp"}]}`
+
+	httpmock.RegisterResponder("POST", testBaseURL+AssetGetRobotAssetByRobotKeyEndpoint, httpmock.NewStringResponder(200, resp))
+
+	asset, err := suite.h.GetRobotAssetByRobotKey("robot-key", "login")
+	assert.Nil(suite.T(), err)
+
+	username, password, err := asset.Credential()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "robot", username)
+	assert.Equal(suite.T(), "hunter2", password)
+	assert.Equal(suite.T(), []string{"region=jp"}, asset.KeyValueList)
+}
+
+func (suite *AssetTestSuite) TestShareAndListFolders() {
+	var sent AssetShareRequest
+
+	httpmock.RegisterResponder("POST", testBaseURL+AssetShareToFoldersEndpoint, func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(body, &sent)
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+	httpmock.RegisterResponder("GET", testBaseURL+AssetGetFoldersForAssetEndpoint+"(id=5)", httpmock.NewStringResponder(200, `{"AccessibleFolders":[{"Id":1,"DisplayName":"Shared"},{"Id":2,"DisplayName":"Finance"}],"TotalFoldersCount":3}`))
+
+	assert.Nil(suite.T(), suite.h.ShareToFolders([]uint{5}, []uint{2}))
+	assert.Equal(suite.T(), AssetShareRequest{AssetIDs: []uint{5}, ToAddFolderIDs: []uint{2}, ToRemoveFolderIDs: []uint{}}, sent)
+
+	folders, count, err := suite.h.GetFoldersForAsset(5)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 3, count)
+	assert.Equal(suite.T(), "Finance", folders[1].DisplayName)
+}
@@ -3,9 +3,6 @@ package uipath
 import (
 	"encoding/json"
 	"errors"
-	"io"
-	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
@@ -79,34 +76,18 @@ func GetOAuthToken(c *Client) (OauthTokenResponse, error) {
 		form.Add(k, v)
 	}
 
-	req, err := http.NewRequest("POST", OauthURL, strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(c.Context(), "POST", c.tokenURL(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return result, err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.HttpClient.Do(req)
+	_, respBody, err := c.do(req, []byte(form.Encode()))
 	if err != nil {
 		return result, err
 	}
 
-	defer func(Body io.ReadCloser, Request *http.Response) {
-		if err := Body.Close(); err != nil {
-			log.Println("Error closing body from response: ", resp)
-		}
-	}(resp.Body, resp)
-
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return result, err
-	}
-
-	// Handle any errors from the response
-	if _, ok := httpSuccessCodes[resp.StatusCode]; !ok {
-		return result, ErrorResponseHandler(resp.StatusCode, respBody)
-	}
-
 	err = json.Unmarshal(respBody, &result)
 	if err != nil {
 		return result, err
@@ -2,12 +2,14 @@ package uipath
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/comvex-jp/uipath-go/configs"
@@ -35,7 +37,30 @@ type Client struct {
 	HttpClient  HttpClientInterface
 	Credentials Credentials
 	BaseURL     string
-	Cache       *cache.Cache
+
+	// Cache keeps the access token between requests, it is created by NewClient and shared by the
+	// copies of WithContext
+	Cache *cache.Cache
+
+	// Instrumentation receives the spans and metrics of every api call, see Instrumentation
+	Instrumentation Instrumentation
+
+	// Middlewares see every api call, the first middleware is the outermost one, see Use
+	Middlewares []Middleware
+
+	// RateLimiter limits the rate and concurrency of the api calls, share it between clients to share the limits
+	RateLimiter *RateLimiter
+
+	ctx context.Context
+
+	// Logger receives the client logs, requests and responses are logged at debug level with their secrets redacted
+	Logger Logger
+
+	// UserAgent is sent with every request when set
+	UserAgent string
+
+	// TokenURL is the identity server token url, it defaults to OauthURL
+	TokenURL string
 }
 
 // Credentials struct defines what items are needed for the client credentials
@@ -53,19 +78,47 @@ type resultCode struct {
 	Result string `json:"result"`
 }
 
-// GetAuthHeaderValue gets the token if it exists and fetches if it does not
+// WithContext returns a shallow copy of the client sending its requests with ctx,
+// handlers created with the copy are canceled with ctx and see its trace
+func (client *Client) WithContext(ctx context.Context) *Client {
+	c := *client
+	c.ctx = ctx
+
+	return &c
+}
+
+// Context returns the context the requests are sent with
+func (client *Client) Context() context.Context {
+	if client.ctx == nil {
+		return context.Background()
+	}
+
+	return client.ctx
+}
+
+// GetAuthHeaderValue gets the token if it exists and fetches if it does not, a client without a cache
+// fetches a token for every request. The client is not modified so its copies can send concurrently.
 func (client *Client) GetAuthHeaderValue() (string, error) {
 	var token string
+	var res interface{}
+	var found bool
+
+	if client.Cache != nil {
+		res, found = client.Cache.Get(configs.UIPathOauthToken)
+	}
 
-	res, found := client.Cache.Get(configs.UIPathOauthToken)
 	if !found {
+		start := time.Now()
+
 		fetchedTokenData, err := GetOAuthToken(client)
 		if err != nil {
-			log.Println("Error fetching access token: ", err.Error())
+			client.logger().Warn("error fetching access token, falling back to user key authentication", "error", err.Error())
 
 			fetchedTokenData, err = DeprecatedGetOAuthToken(client)
 		}
 
+		client.instrumentation().RecordTokenRefresh(time.Since(start), err)
+
 		if err != nil {
 			return token, err
 		}
@@ -80,7 +133,10 @@ func (client *Client) GetAuthHeaderValue() (string, error) {
 			return token, err
 		}
 
-		client.Cache.Set(configs.UIPathOauthToken, token, parsedExpiresIn)
+		if client.Cache != nil {
+			client.Cache.Set(configs.UIPathOauthToken, token, parsedExpiresIn)
+		}
+
 		return token, nil
 	}
 
@@ -94,7 +150,7 @@ func (client Client) Send(requestMethod string, url string, body interface{}, he
 		return jsonBody, err
 	}
 
-	req, err := http.NewRequest(requestMethod, url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(client.Context(), requestMethod, url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return jsonBody, err
 	}
@@ -111,45 +167,164 @@ func (client Client) Send(requestMethod string, url string, body interface{}, he
 
 	attachHeaders(req, headers)
 
-	resp, err := client.HttpClient.Do(req)
+	resp, respBody, err := client.do(req, jsonBody)
+	if resp == nil {
+		return jsonBody, err
+	}
+
+	return respBody, err
+}
+
+// SendWithAuthorization attaches the authorization token to the headers and then completes the request
+func (client *Client) SendWithAuthorization(requestMethod, url string, body interface{}, headers map[string]string, queryParams map[string]string) ([]byte, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return jsonBody, err
+	}
+
+	token, err := client.GetAuthHeaderValue()
 	if err != nil {
 		return jsonBody, err
 	}
 
+	headers[HeaderAuthorization] = "Bearer " + token
+
+	return client.Send(requestMethod, url, body, headers, queryParams)
+}
+
+// do sends the prepared request through the middlewares and reads the response,
+// the response is nil when it could not be received
+func (client *Client) do(req *http.Request, body []byte) (*http.Response, []byte, error) {
+	if client.UserAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", client.UserAgent)
+	}
+
+	info := client.requestInfo(req)
+
+	req, span := client.instrumentation().StartRequest(req, info)
+	start := time.Now()
+
+	call := &Call{
+		Request:  req,
+		Body:     body,
+		Endpoint: info.Endpoint,
+		FolderID: info.FolderID,
+	}
+
+	invoker := client.send
+	if client.RateLimiter != nil {
+		invoker = client.RateLimiter.Middleware()(invoker)
+	}
+
+	resp, respBody, err := client.chain(invoker)(call)
+
+	result := RequestResult{Duration: time.Since(start), Err: err}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		result.ErrorCode = apiError.ErrorCode
+		result.TraceID = apiError.TraceID
+	}
+
+	span.End(result)
+
+	return resp, respBody, err
+}
+
+// send is the last invoker of the middleware chain, it sends the request over the http client
+func (client *Client) send(call *Call) (*http.Response, []byte, error) {
+	req := call.Request
+
+	client.logRequest(req, call.Body)
+	start := time.Now()
+
+	resp, err := client.HttpClient.Do(req)
+	if err != nil {
+		client.logger().Debug("uipath request failed", "method", req.Method, "url", req.URL.String(), "error", err.Error())
+		return nil, nil, requestError(req, err)
+	}
+
 	defer func(Body io.ReadCloser, Request *http.Response) {
 		if err := Body.Close(); err != nil {
-			log.Println("Error closing body from response: ", resp)
+			client.logger().Warn("error closing response body", "method", req.Method, "url", req.URL.String(), "error", err.Error())
 		}
 	}(resp.Body, resp)
 
 	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return jsonBody, err
+		return nil, nil, requestError(req, err)
 	}
 
+	client.logResponse(req, resp, respBody, time.Since(start))
+
 	// Handle any errors from the response
 	if _, ok := httpSuccessCodes[resp.StatusCode]; !ok {
-		return respBody, ErrorResponseHandler(resp.StatusCode, respBody)
+		apiError := newAPIError(resp.StatusCode, respBody)
+		apiError.Method = req.Method
+		apiError.URL = req.URL.String()
+
+		return resp, respBody, apiError
 	}
 
-	return respBody, err
+	return resp, respBody, nil
 }
 
-// SendWithAuthorization attaches the authorization token to the headers and then completes the request
-func (client *Client) SendWithAuthorization(requestMethod, url string, body interface{}, headers map[string]string, queryParams map[string]string) ([]byte, error) {
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return jsonBody, err
+// tokenURL returns the identity server token url
+func (client *Client) tokenURL() string {
+	if client.TokenURL == "" {
+		return OauthURL
 	}
 
-	token, err := client.GetAuthHeaderValue()
-	if err != nil {
-		return jsonBody, err
+	return client.TokenURL
+}
+
+// rootURL returns the orchestrator url without the odata path for the non-odata endpoints
+func (client *Client) rootURL() string {
+	return strings.TrimSuffix(client.BaseURL, "odata/")
+}
+
+// logRequest logs the outgoing request at debug level without its secrets
+func (client *Client) logRequest(req *http.Request, body []byte) {
+	if client.Logger == nil {
+		return
 	}
 
-	headers[HeaderAuthorization] = "Bearer " + token
+	client.Logger.Debug("uipath request",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"headers", RedactHeaders(req.Header),
+		"body", RedactBody(body, req.Header.Get("Content-Type")),
+	)
+}
 
-	return client.Send(requestMethod, url, body, headers, queryParams)
+// logResponse logs the response at debug level without its secrets
+func (client *Client) logResponse(req *http.Request, resp *http.Response, body []byte, duration time.Duration) {
+	if client.Logger == nil {
+		return
+	}
+
+	client.Logger.Debug("uipath response",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"status", resp.StatusCode,
+		"duration", duration,
+		"body", RedactBody(body, resp.Header.Get("Content-Type")),
+	)
+}
+
+// tenantHeaders builds the headers of the handlers for tenant entities, like users or roles, they
+// do not belong to a folder so the folder header is only sent when the handler is given one
+func tenantHeaders(folderID uint) map[string]string {
+	var headers = map[string]string{}
+
+	if folderID != 0 {
+		headers[HeaderOrganizationUnitId] = strconv.Itoa(int(folderID))
+	}
+
+	return headers
 }
 
 func attachHeaders(req *http.Request, headers map[string]string) {
@@ -0,0 +1,256 @@
+package uipath
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	// CloudURL is the url of the UiPath automation cloud
+	CloudURL = "https://cloud.uipath.com/"
+
+	DefaultTimeout   = 30 * time.Second
+	DefaultUserAgent = "uipath-go"
+)
+
+// Errors returned by NewClient when the configuration is not valid
+var (
+	ErrMissingBaseURL     = errors.New("uipath: missing base url, set it or the organization and tenant names")
+	ErrInvalidBaseURL     = errors.New("uipath: invalid base url")
+	ErrMissingCredentials = errors.New("uipath: missing credentials, set the application id and secret")
+)
+
+// Option configures the client built by NewClient
+type Option func(o *clientOptions) error
+
+type clientOptions struct {
+	client       *Client
+	cloudURL     string
+	organization string
+	tenant       string
+	timeout      time.Duration
+}
+
+// NewClient builds a client with sensible defaults and validates its configuration
+func NewClient(opts ...Option) (*Client, error) {
+	o := &clientOptions{
+		client: &Client{
+			UserAgent: DefaultUserAgent,
+		},
+		cloudURL: CloudURL,
+		timeout:  DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	c := o.client
+
+	if c.HttpClient == nil {
+		c.HttpClient = &http.Client{Timeout: o.timeout}
+	}
+
+	if c.Cache == nil {
+		c.Cache = cache.New(5*time.Minute, 10*time.Minute)
+	}
+
+	if c.BaseURL == "" && o.organization != "" && o.tenant != "" {
+		c.BaseURL = fmt.Sprintf("%s%s/%s/orchestrator_/odata/", normalizeURL(o.cloudURL), url.PathEscape(o.organization), url.PathEscape(o.tenant))
+	}
+
+	if c.Credentials.TenantName == "" {
+		c.Credentials.TenantName = o.tenant
+	}
+
+	if c.TokenURL == "" && normalizeURL(o.cloudURL) != CloudURL {
+		c.TokenURL = cloudTokenURL(o.cloudURL)
+	}
+
+	baseURL, err := NormalizeBaseURL(c.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c.BaseURL = baseURL
+
+	if err := validateCredentials(c.Credentials); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NormalizeBaseURL validates the orchestrator url and makes it end with odata/ so endpoints can be appended to it
+func NormalizeBaseURL(baseURL string) (string, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" {
+		return baseURL, ErrMissingBaseURL
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return baseURL, fmt.Errorf("%w: %q", ErrInvalidBaseURL, baseURL)
+	}
+
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return baseURL, fmt.Errorf("%w: %q must not have a query or fragment", ErrInvalidBaseURL, baseURL)
+	}
+
+	baseURL = normalizeURL(baseURL)
+	if !strings.HasSuffix(baseURL, "odata/") {
+		baseURL += "odata/"
+	}
+
+	return baseURL, nil
+}
+
+// WithHTTPClient sets the http client used to send the requests
+func WithHTTPClient(httpClient HttpClientInterface) Option {
+	return func(o *clientOptions) error {
+		o.client.HttpClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of the default http client
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithCredentials sets the credentials of the client
+func WithCredentials(credentials Credentials) Option {
+	return func(o *clientOptions) error {
+		o.client.Credentials = credentials
+		return nil
+	}
+}
+
+// WithApplicationCredentials sets the external application id, secret and scopes used to fetch the token
+func WithApplicationCredentials(applicationID string, applicationSecret string, scopes string) Option {
+	return func(o *clientOptions) error {
+		o.client.Credentials.ApplicationID = applicationID
+		o.client.Credentials.ApplicationSecret = applicationSecret
+		o.client.Credentials.Scopes = scopes
+		return nil
+	}
+}
+
+// WithBaseURL sets the orchestrator odata url, eg. https://cloud.uipath.com/org/tenant/orchestrator_/odata/
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) error {
+		o.client.BaseURL = baseURL
+		return nil
+	}
+}
+
+// WithOrganization builds the base url from the organization and tenant names, and sets the tenant name
+func WithOrganization(organization string, tenant string) Option {
+	return func(o *clientOptions) error {
+		o.organization = organization
+		o.tenant = tenant
+		return nil
+	}
+}
+
+// WithCloudURL sets the cloud url used with WithOrganization, it defaults to CloudURL. The token is
+// fetched from the identity server of the cloud unless WithTokenURL is given.
+func WithCloudURL(cloudURL string) Option {
+	return func(o *clientOptions) error {
+		o.cloudURL = cloudURL
+		return nil
+	}
+}
+
+// WithTokenURL sets the identity server token url, eg. for automation suite or on-premise installs
+func WithTokenURL(tokenURL string) Option {
+	return func(o *clientOptions) error {
+		o.client.TokenURL = tokenURL
+		return nil
+	}
+}
+
+// WithCache sets the cache holding the access token
+func WithCache(c *cache.Cache) Option {
+	return func(o *clientOptions) error {
+		o.client.Cache = c
+		return nil
+	}
+}
+
+// WithUserAgent sets the user agent header of the requests
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) error {
+		o.client.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the client
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) error {
+		o.client.Logger = logger
+		return nil
+	}
+}
+
+// WithInstrumentation sets the tracing and metrics instrumentation of the client
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(o *clientOptions) error {
+		o.client.Instrumentation = instrumentation
+		return nil
+	}
+}
+
+// WithMiddlewares appends middlewares to the chain of the client
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(o *clientOptions) error {
+		o.client.Use(middlewares...)
+		return nil
+	}
+}
+
+// WithRateLimiter sets the rate limiter of the client
+func WithRateLimiter(rateLimiter *RateLimiter) Option {
+	return func(o *clientOptions) error {
+		o.client.RateLimiter = rateLimiter
+		return nil
+	}
+}
+
+func validateCredentials(credentials Credentials) error {
+	if credentials.ApplicationID != "" && credentials.ApplicationSecret != "" {
+		return nil
+	}
+
+	if credentials.ClientID != "" && credentials.UserKey != "" {
+		return nil
+	}
+
+	return ErrMissingCredentials
+}
+
+// cloudTokenURL returns the token url of the identity server of a cloud
+func cloudTokenURL(cloudURL string) string {
+	return normalizeURL(cloudURL) + "identity_/connect/token"
+}
+
+func normalizeURL(u string) string {
+	u = strings.TrimSpace(u)
+	if !strings.HasSuffix(u, "/") {
+		u += "/"
+	}
+
+	return u
+}
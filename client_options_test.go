@@ -0,0 +1,153 @@
+package uipath
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientDefaults(t *testing.T) {
+	c, err := NewClient(
+		WithOrganization("exampleOrg", "exampleTenant"),
+		WithApplicationCredentials("appID", "appSecret", "OR.Assets"),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://cloud.uipath.com/exampleOrg/exampleTenant/orchestrator_/odata/", c.BaseURL)
+	assert.Equal(t, "exampleTenant", c.Credentials.TenantName)
+	assert.Equal(t, DefaultUserAgent, c.UserAgent)
+	assert.NotNil(t, c.Cache)
+	assert.Equal(t, DefaultTimeout, c.HttpClient.(*http.Client).Timeout)
+}
+
+func TestNewClientTokenURLFollowsCloudURL(t *testing.T) {
+	c, err := NewClient(
+		WithOrganization("exampleOrg", "exampleTenant"),
+		WithCloudURL("https://staging.uipath.com"),
+		WithApplicationCredentials("appID", "appSecret", "OR.Assets"),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://staging.uipath.com/exampleOrg/exampleTenant/orchestrator_/odata/", c.BaseURL)
+	assert.Equal(t, "https://staging.uipath.com/identity_/connect/token", c.tokenURL())
+
+	c, err = NewClient(
+		WithOrganization("exampleOrg", "exampleTenant"),
+		WithCloudURL("https://staging.uipath.com"),
+		WithTokenURL("https://identity.example.com/connect/token"),
+		WithApplicationCredentials("appID", "appSecret", "OR.Assets"),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://identity.example.com/connect/token", c.tokenURL())
+
+	c, err = NewClient(
+		WithOrganization("exampleOrg", "exampleTenant"),
+		WithApplicationCredentials("appID", "appSecret", "OR.Assets"),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, OauthURL, c.tokenURL())
+}
+
+func TestNewClientNormalizesBaseURL(t *testing.T) {
+	c, err := NewClient(
+		WithBaseURL(" https://orchestrator.example.com/org/tenant/orchestrator_ "),
+		WithApplicationCredentials("appID", "appSecret", ""),
+		WithTimeout(5*time.Second),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://orchestrator.example.com/org/tenant/orchestrator_/odata/", c.BaseURL)
+	assert.Equal(t, 5*time.Second, c.HttpClient.(*http.Client).Timeout)
+}
+
+func TestNewClientValidation(t *testing.T) {
+	_, err := NewClient(WithApplicationCredentials("appID", "appSecret", ""))
+	assert.ErrorIs(t, err, ErrMissingBaseURL)
+
+	_, err = NewClient(WithBaseURL("cloud.uipath.com/org/tenant"), WithApplicationCredentials("appID", "appSecret", ""))
+	assert.ErrorIs(t, err, ErrInvalidBaseURL)
+
+	_, err = NewClient(WithOrganization("exampleOrg", "exampleTenant"))
+	assert.ErrorIs(t, err, ErrMissingCredentials)
+}
+
+func TestNewClientSendsUserAgentAndUsesTokenURL(t *testing.T) {
+	var urls []string
+	var userAgents []string
+
+	c, err := NewClient(
+		WithOrganization("exampleOrg", "exampleTenant"),
+		WithApplicationCredentials("appID", "appSecret", ""),
+		WithTokenURL("https://orchestrator.example.com/identity/connect/token"),
+		WithUserAgent("my-robot/1.0"),
+		WithHTTPClient(&httpClientMock{
+			MockedDo: func(req *http.Request) (*http.Response, error) {
+				urls = append(urls, req.URL.String())
+				userAgents = append(userAgents, req.Header.Get("User-Agent"))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"access_token": "token", "expires_in": 3600}`))),
+				}, nil
+			},
+		}),
+	)
+	assert.Nil(t, err)
+
+	_, err = c.SendWithAuthorization("GET", c.BaseURL+"Assets", nil, map[string]string{}, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"https://orchestrator.example.com/identity/connect/token", c.BaseURL + "Assets"}, urls)
+	assert.Equal(t, []string{"my-robot/1.0", "my-robot/1.0"}, userAgents)
+}
+
+func TestGetAuthHeaderValueWithoutCache(t *testing.T) {
+	var fetched int32
+
+	c := &Client{
+		HttpClient: &httpClientMock{
+			MockedDo: func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&fetched, 1)
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"access_token": "token", "expires_in": 3600}`))),
+				}, nil
+			},
+		},
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		client := c
+		if i%2 == 1 {
+			client = c.WithContext(context.Background())
+		}
+
+		go func() {
+			defer wg.Done()
+
+			token, err := client.GetAuthHeaderValue()
+
+			assert.Nil(t, err)
+			assert.Equal(t, "token", token)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Nil(t, c.Cache)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&fetched))
+}
@@ -3,6 +3,7 @@ package uipath
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"testing"
@@ -190,3 +191,157 @@ func (suite *ClientTestSuite) PrepareUIPathAuthAPIResponder(providedData OauthTo
 	httpmock.RegisterResponder(method, mockURL,
 		httpmock.NewStringResponder(HTTPStatusCode, string(mockResponse)))
 }
+
+type logEntry struct {
+	Level   string
+	Message string
+	Args    []interface{}
+}
+
+type loggerMock struct {
+	Entries []logEntry
+}
+
+func (l *loggerMock) Debug(msg string, args ...interface{}) { l.add("DEBUG", msg, args) }
+func (l *loggerMock) Info(msg string, args ...interface{})  { l.add("INFO", msg, args) }
+func (l *loggerMock) Warn(msg string, args ...interface{})  { l.add("WARN", msg, args) }
+func (l *loggerMock) Error(msg string, args ...interface{}) { l.add("ERROR", msg, args) }
+
+func (l *loggerMock) add(level string, msg string, args []interface{}) {
+	l.Entries = append(l.Entries, logEntry{Level: level, Message: msg, Args: args})
+}
+
+func (suite *ClientTestSuite) TestSendLogsRedactedRequestAndResponse() {
+	logger := &loggerMock{}
+	resBody := `{"Id":1,"Name":"login","CredentialPassword":"hunter2"}`
+
+	suite.c.Logger = logger
+	suite.c.HttpClient = &httpClientMock{
+		MockedDo: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(resBody))),
+				},
+				nil
+		},
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 1*time.Minute)
+
+	_, err := suite.c.SendWithAuthorization("POST", "/", NewCredentialAsset("login", "robot", "hunter1"), map[string]string{}, nil)
+	assert.Nil(suite.T(), err)
+
+	printed := fmt.Sprint(logger.Entries)
+
+	assert.Len(suite.T(), logger.Entries, 2)
+	assert.Contains(suite.T(), printed, "robot")
+	assert.NotContains(suite.T(), printed, "=testToken=")
+	assert.NotContains(suite.T(), printed, "hunter1")
+	assert.NotContains(suite.T(), printed, "hunter2")
+}
+
+type instrumentationMock struct {
+	Infos          []RequestInfo
+	Results        []RequestResult
+	TokenRefreshes int
+}
+
+type spanMock struct {
+	i *instrumentationMock
+}
+
+func (i *instrumentationMock) StartRequest(req *http.Request, info RequestInfo) (*http.Request, RequestSpan) {
+	i.Infos = append(i.Infos, info)
+	req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+
+	return req, spanMock{i: i}
+}
+
+func (i *instrumentationMock) RecordRetry(info RequestInfo, attempt int, err error) {}
+
+func (i *instrumentationMock) RecordTokenRefresh(duration time.Duration, err error) {
+	i.TokenRefreshes++
+}
+
+func (s spanMock) End(result RequestResult) {
+	s.i.Results = append(s.i.Results, result)
+}
+
+func (suite *ClientTestSuite) TestSendIsInstrumented() {
+	instrumentation := &instrumentationMock{}
+	var traceparent string
+
+	suite.c.Instrumentation = instrumentation
+	suite.c.BaseURL = "https://cloud.uipath.com/org/tenant/orchestrator_/odata/"
+	suite.c.HttpClient = &httpClientMock{
+		MockedDo: func(req *http.Request) (*http.Response, error) {
+			traceparent = req.Header.Get("traceparent")
+
+			return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message":"Asset does not exist.","errorCode":1002,"traceId":"00-abc-01"}`))),
+				},
+				nil
+		},
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 1*time.Minute)
+
+	h := AssetHandler{Client: suite.c, FolderId: 42}
+	_, err := h.GetByID(7)
+
+	assert.NotNil(suite.T(), err)
+	assert.Equal(suite.T(), []RequestInfo{{Method: "GET", Endpoint: "Assets({key})", FolderID: 42}}, instrumentation.Infos)
+	assert.Equal(suite.T(), http.StatusNotFound, instrumentation.Results[0].StatusCode)
+	assert.Equal(suite.T(), ItemNotFoundCode, instrumentation.Results[0].ErrorCode)
+	assert.Equal(suite.T(), "00-abc-01", instrumentation.Results[0].TraceID)
+	assert.NotEmpty(suite.T(), traceparent)
+}
+
+func (suite *ClientTestSuite) TestMiddlewareChain() {
+	var order []string
+	var seen *Call
+	var seenErr error
+	var sentHeader string
+
+	suite.c.BaseURL = "https://cloud.uipath.com/org/tenant/orchestrator_/odata/"
+	suite.c.HttpClient = &httpClientMock{
+		MockedDo: func(req *http.Request) (*http.Response, error) {
+			sentHeader = req.Header.Get("X-Audit")
+
+			return &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message":"Duplicate Reference.","errorCode":1016}`))),
+				},
+				nil
+		},
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 1*time.Minute)
+
+	suite.c.Use(
+		func(next Invoker) Invoker {
+			return func(call *Call) (*http.Response, []byte, error) {
+				order = append(order, "outer")
+				resp, body, err := next(call)
+				seen, seenErr = call, err
+
+				return resp, body, err
+			}
+		},
+		HeaderMiddleware(map[string]string{"X-Audit": "batch-7"}),
+		func(next Invoker) Invoker {
+			return func(call *Call) (*http.Response, []byte, error) {
+				order = append(order, "inner")
+				return next(call)
+			}
+		},
+	)
+
+	h := QueueItemHandler{Client: suite.c, FolderId: 3}
+	_, err := h.Store(QueueItem{Name: "Invoices", Reference: "INV-1"})
+
+	assert.ErrorIs(suite.T(), err, ErrDuplicateReference)
+	assert.ErrorIs(suite.T(), seenErr, ErrDuplicateReference)
+	assert.Equal(suite.T(), []string{"outer", "inner"}, order)
+	assert.Equal(suite.T(), QueueAddItemEndpoint, seen.Endpoint)
+	assert.Equal(suite.T(), uint(3), seen.FolderID)
+	assert.Equal(suite.T(), "batch-7", sentHeader)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/comvex-jp/uipath-go"
+)
+
+var assetHeaders = []string{"ID", "NAME", "TYPE", "SCOPE", "VALUE"}
+
+func assetsList(env *environment, args []string) error {
+	fs := env.flags("assets list")
+	filter := fs.String("filter", "", "odata $filter, eg. startswith(Name,'Prod')")
+
+	if err := env.parse(fs, args); err != nil {
+		return err
+	}
+
+	filters := map[string]string{}
+	if *filter != "" {
+		filters["$filter"] = *filter
+	}
+
+	handler := uipath.AssetHandler{Client: env.client, FolderId: env.config.FolderID}
+
+	assets, err := handler.ListAll(filters)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for i := range assets {
+		assets[i] = withoutSecrets(assets[i])
+		rows = append(rows, assetRow(assets[i]))
+	}
+
+	return env.print(assets, assetHeaders, rows)
+}
+
+func assetsGet(env *environment, args []string) error {
+	fs := env.flags("assets get")
+	name := fs.String("name", "", "asset name")
+
+	if err := env.parse(fs, args, "name"); err != nil {
+		return err
+	}
+
+	handler := uipath.AssetHandler{Client: env.client, FolderId: env.config.FolderID}
+
+	asset, err := handler.GetByName(*name)
+	if err != nil {
+		return err
+	}
+
+	if asset.ID == 0 {
+		return fmt.Errorf("asset %q: %w", *name, uipath.ErrNotFound)
+	}
+
+	asset = withoutSecrets(asset)
+
+	return env.print(asset, assetHeaders, [][]string{assetRow(asset)})
+}
+
+// withoutSecrets redacts the secret values in case the orchestrator returned them
+func withoutSecrets(asset uipath.Asset) uipath.Asset {
+	if asset.CredentialPassword != "" {
+		asset.CredentialPassword = uipath.RedactedValue
+	}
+
+	if asset.SecretValue != "" {
+		asset.SecretValue = uipath.RedactedValue
+	}
+
+	return asset
+}
+
+func assetRow(asset uipath.Asset) []string {
+	return []string{strconv.Itoa(int(asset.ID)), asset.Name, asset.ValueType, asset.ValueScope, asset.Value}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/comvex-jp/uipath-go"
+)
+
+// Exit codes of the command, they let scripts tell why a command failed
+const (
+	ExitOK           = 0
+	ExitError        = 1
+	ExitUsage        = 2
+	ExitConfig       = 3
+	ExitUnauthorized = 4
+	ExitNotFound     = 5
+	ExitConflict     = 6
+	ExitValidation   = 7
+	ExitUnavailable  = 8
+	ExitJobFailed    = 9
+	ExitCanceled     = 130
+)
+
+// usageError is returned when the command line is not valid
+type usageError struct{ err error }
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+// configError is returned when the client cannot be configured
+type configError struct{ err error }
+
+func (e configError) Error() string { return e.err.Error() }
+func (e configError) Unwrap() error { return e.err }
+
+// jobFailedError is returned when a job ends in another state than successful
+type jobFailedError struct{ job uipath.Job }
+
+func (e jobFailedError) Error() string {
+	if e.job.Info != "" {
+		return fmt.Sprintf("job %d ended %s: %s", e.job.ID, e.job.State, e.job.Info)
+	}
+
+	return fmt.Sprintf("job %d ended %s", e.job.ID, e.job.State)
+}
+
+// exitCode derives the exit code from the error returned by a command
+func exitCode(err error) int {
+	var usage usageError
+	var config configError
+	var jobFailed jobFailedError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usage):
+		return ExitUsage
+	case errors.As(err, &config):
+		return ExitConfig
+	case errors.As(err, &jobFailed):
+		return ExitJobFailed
+	case errors.Is(err, uipath.ErrNotFound):
+		return ExitNotFound
+	}
+
+	switch uipath.CategoryOf(err) {
+	case uipath.ErrorCategoryUnauthorized, uipath.ErrorCategoryForbidden:
+		return ExitUnauthorized
+	case uipath.ErrorCategoryNotFound:
+		return ExitNotFound
+	case uipath.ErrorCategoryConflict:
+		return ExitConflict
+	case uipath.ErrorCategoryValidation:
+		return ExitValidation
+	case uipath.ErrorCategoryRateLimited, uipath.ErrorCategoryTransient, uipath.ErrorCategoryNetwork,
+		uipath.ErrorCategoryLicense, uipath.ErrorCategoryRobot:
+		return ExitUnavailable
+	case uipath.ErrorCategoryCanceled:
+		return ExitCanceled
+	}
+
+	return ExitError
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/comvex-jp/uipath-go"
+)
+
+var jobHeaders = []string{"ID", "KEY", "PROCESS", "STATE", "START", "END", "INFO"}
+
+func jobsStart(env *environment, args []string) error {
+	fs := env.flags("jobs start")
+	process := fs.String("process", "", "process, or release, name")
+	input := fs.String("input", "", "input arguments as a json object")
+	count := fs.Int("count", 1, "number of jobs to start")
+	reference := fs.String("reference", "", "job reference")
+	wait := fs.Bool("wait", false, "wait until the jobs end")
+	interval := fs.Duration("interval", 5*time.Second, "polling interval when waiting")
+	timeout := fs.Duration("timeout", 0, "maximum time to wait, zero waits forever")
+
+	if err := env.parse(fs, args, "process"); err != nil {
+		return err
+	}
+
+	if *input != "" && !json.Valid([]byte(*input)) {
+		return usageError{fmt.Errorf("--input is not valid json")}
+	}
+
+	releases := uipath.ReleaseHandler{Client: env.client, FolderId: env.config.FolderID}
+
+	release, err := releases.GetByName(*process)
+	if err != nil {
+		return err
+	}
+
+	if release.ID == 0 {
+		return fmt.Errorf("process %q: %w", *process, uipath.ErrNotFound)
+	}
+
+	handler := uipath.JobHandler{Client: env.client, FolderId: env.config.FolderID}
+
+	jobs, err := handler.Start(uipath.StartJobsInfo{
+		ReleaseKey:     release.Key,
+		JobsCount:      *count,
+		InputArguments: *input,
+		Reference:      *reference,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *wait {
+		return waitJobs(env, jobIDs(jobs), *interval, *timeout)
+	}
+
+	return env.print(jobs, jobHeaders, jobRows(jobs))
+}
+
+func jobsWait(env *environment, args []string) error {
+	fs := env.flags("jobs wait")
+	id := fs.Uint("id", 0, "job id")
+	interval := fs.Duration("interval", 5*time.Second, "polling interval")
+	timeout := fs.Duration("timeout", 0, "maximum time to wait, zero waits forever")
+
+	if err := env.parse(fs, args, "id"); err != nil {
+		return err
+	}
+
+	return waitJobs(env, []uint{*id}, *interval, *timeout)
+}
+
+// waitJobs waits for the jobs to end, prints them, and fails when one of them did not succeed
+func waitJobs(env *environment, ids []uint, interval time.Duration, timeout time.Duration) error {
+	ctx := env.ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	handler := uipath.JobHandler{Client: env.client.WithContext(ctx), FolderId: env.config.FolderID}
+
+	var jobs []uipath.Job
+	for _, id := range ids {
+		job, err := handler.Wait(id, interval)
+		if err != nil {
+			return err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if err := env.print(jobs, jobHeaders, jobRows(jobs)); err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.State != uipath.JobStateSuccessful {
+			return jobFailedError{job}
+		}
+	}
+
+	return nil
+}
+
+func jobIDs(jobs []uipath.Job) []uint {
+	var ids []uint
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+
+	return ids
+}
+
+func jobRows(jobs []uipath.Job) [][]string {
+	var rows [][]string
+	for _, job := range jobs {
+		rows = append(rows, []string{strconv.Itoa(int(job.ID)), job.Key, job.ReleaseName, job.State, job.StartTime, job.EndTime, job.Info})
+	}
+
+	return rows
+}
@@ -0,0 +1,195 @@
+// Command uipath runs orchestrator operations from shells and CI.
+//
+// Usage:
+//
+//	uipath [command] [subcommand] [flags]
+//
+//	uipath assets list
+//	uipath assets get --name NAME
+//	uipath queue add --queue NAME --file items.csv
+//	uipath jobs start --process NAME [--input '{"arg": 1}'] [--wait]
+//	uipath jobs wait --id ID
+//
+// The client is configured like uipath.LoadConfig, from the UIPATH_* environment variables,
+// the --config profile file and the UiPath CLI config, see --profile.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/comvex-jp/uipath-go"
+)
+
+const usage = `usage: uipath <command> <subcommand> [flags]
+
+commands:
+  assets list                  list the assets of the folder
+  assets get --name NAME       get an asset by name
+  queue add --queue NAME --file FILE
+                               add the items of a csv file to a queue
+  jobs start --process NAME    start a job, --wait waits until it ends
+  jobs wait --id ID            wait until a job ends
+
+common flags:
+  --profile NAME    config profile, defaults to UIPATH_PROFILE
+  --config FILE     profile file, defaults to UIPATH_CONFIG
+  --folder ID       folder id, defaults to UIPATH_FOLDER_ID or the profile one
+  --output FORMAT   table, json or yaml, defaults to table
+`
+
+// command runs a subcommand with its own flags
+type command func(env *environment, args []string) error
+
+var commands = map[string]command{
+	"assets list": assetsList,
+	"assets get":  assetsGet,
+	"queue add":   queueAdd,
+	"jobs start":  jobsStart,
+	"jobs wait":   jobsWait,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+
+	os.Exit(code)
+}
+
+// run executes the command line and returns the exit code
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) < 2 {
+		fmt.Fprint(stderr, usage)
+		return ExitUsage
+	}
+
+	name := args[0] + " " + args[1]
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", name, usage)
+		return ExitUsage
+	}
+
+	env := &environment{ctx: ctx, stdout: stdout, stderr: stderr}
+
+	err := cmd(env, args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "uipath %s: %s\n", name, err)
+	}
+
+	return exitCode(err)
+}
+
+// environment holds what the commands share
+type environment struct {
+	ctx    context.Context
+	stdout io.Writer
+	stderr io.Writer
+
+	profile    string
+	configFile string
+	folderID   uint
+	output     string
+
+	client *uipath.Client
+	config uipath.Config
+}
+
+// flags creates the flag set of a subcommand with the common flags
+func (env *environment) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+
+	fs.StringVar(&env.profile, "profile", "", "config profile")
+	fs.StringVar(&env.configFile, "config", "", "profile file")
+	fs.UintVar(&env.folderID, "folder", 0, "folder id")
+	fs.StringVar(&env.output, "output", formatTable, "output format: table, json or yaml")
+
+	return fs
+}
+
+// parse parses the flags, checks the required ones are set and builds the client
+func (env *environment) parse(fs *flag.FlagSet, args []string, requiredFlags ...string) error {
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+
+	if fs.NArg() > 0 {
+		return usageError{fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))}
+	}
+
+	if err := required(fs, requiredFlags...); err != nil {
+		return err
+	}
+
+	if _, ok := printers[env.output]; !ok {
+		return usageError{fmt.Errorf("unknown output format %q, use one of %s", env.output, strings.Join(formats(), ", "))}
+	}
+
+	config, err := uipath.ConfigLoader{Profile: env.profile, File: env.configFile}.Load()
+	if err != nil {
+		return configError{err}
+	}
+
+	if env.folderID != 0 {
+		config.FolderID = env.folderID
+	}
+
+	client, err := config.NewClient()
+	if err != nil {
+		return configError{err}
+	}
+
+	env.config = config
+	env.client = client.WithContext(env.ctx)
+
+	return nil
+}
+
+// print writes the value in the selected output format
+func (env *environment) print(value interface{}, headers []string, rows [][]string) error {
+	return printers[env.output](env.stdout, value, headers, rows)
+}
+
+// required checks the required flags are set
+func required(fs *flag.FlagSet, names ...string) error {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var missing []string
+	for _, name := range names {
+		if !set[name] {
+			missing = append(missing, "--"+name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return usageError{fmt.Errorf("missing required flags: %s", strings.Join(missing, ", "))}
+	}
+
+	return nil
+}
+
+func formats() []string {
+	var names []string
+	for name := range printers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupOrchestrator serves the token endpoint and the given odata routes, and points the config at it
+func setupOrchestrator(t *testing.T, routes map[string]string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity_/connect/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token": "token", "expires_in": 3600}`))
+	})
+
+	mux.HandleFunc("/org/tenant/orchestrator_/odata/", func(w http.ResponseWriter, r *http.Request) {
+		route := strings.TrimPrefix(r.URL.Path, "/org/tenant/orchestrator_/odata/")
+
+		body, ok := routes[route]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found", "errorCode": 1002}`))
+			return
+		}
+
+		w.Write([]byte(body))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(uipath.EnvConfigFile, "")
+	t.Setenv(uipath.EnvProfile, "")
+	t.Setenv(uipath.EnvBaseURL, server.URL+"/org/tenant/orchestrator_/")
+	t.Setenv(uipath.EnvTokenURL, server.URL+"/identity_/connect/token")
+	t.Setenv(uipath.EnvAppID, "appID")
+	t.Setenv(uipath.EnvAppSecret, "appSecret")
+}
+
+func TestAssetsGet(t *testing.T) {
+	setupOrchestrator(t, map[string]string{
+		"Assets": `{"@odata.count": 1, "value": [{"Id": 1, "Name": "Password", "ValueType": "Credential", "CredentialUsername": "user", "CredentialPassword": "secret"}]}`,
+	})
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(context.Background(), []string{"assets", "get", "--name", "Password", "--output", "json"}, &stdout, &stderr)
+
+	assert.Equal(t, ExitOK, code, stderr.String())
+
+	var asset map[string]interface{}
+	assert.Nil(t, json.Unmarshal(stdout.Bytes(), &asset))
+	assert.Equal(t, "Password", asset["Name"])
+	assert.NotContains(t, stdout.String(), "secret")
+}
+
+func TestAssetsGetNotFound(t *testing.T) {
+	setupOrchestrator(t, map[string]string{
+		"Assets": `{"@odata.count": 0, "value": []}`,
+	})
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(context.Background(), []string{"assets", "get", "--name", "Missing"}, &stdout, &stderr)
+
+	assert.Equal(t, ExitNotFound, code)
+	assert.Contains(t, stderr.String(), `asset "Missing"`)
+}
+
+func TestJobsWaitFailed(t *testing.T) {
+	setupOrchestrator(t, map[string]string{
+		"Jobs(7)": `{"Id": 7, "State": "Faulted", "ReleaseName": "Invoices", "Info": "boom"}`,
+	})
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(context.Background(), []string{"jobs", "wait", "--id", "7"}, &stdout, &stderr)
+
+	assert.Equal(t, ExitJobFailed, code)
+	assert.Contains(t, stdout.String(), "Faulted")
+	assert.Contains(t, stderr.String(), "job 7 ended Faulted: boom")
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitOK, exitCode(nil))
+	assert.Equal(t, ExitUsage, exitCode(usageError{errors.New("bad flag")}))
+	assert.Equal(t, ExitConfig, exitCode(configError{uipath.ErrMissingCredentials}))
+	assert.Equal(t, ExitUnauthorized, exitCode(&uipath.APIError{StatusCode: http.StatusUnauthorized}))
+	assert.Equal(t, ExitNotFound, exitCode(&uipath.APIError{StatusCode: http.StatusNotFound}))
+	assert.Equal(t, ExitConflict, exitCode(&uipath.APIError{StatusCode: http.StatusConflict}))
+	assert.Equal(t, ExitUnavailable, exitCode(&uipath.APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.Equal(t, ExitCanceled, exitCode(context.Canceled))
+	assert.Equal(t, ExitError, exitCode(errors.New("boom")))
+}
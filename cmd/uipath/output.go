@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// printer writes a command result, table printers use the headers and rows, the others the value
+type printer func(w io.Writer, value interface{}, headers []string, rows [][]string) error
+
+var printers = map[string]printer{
+	formatTable: printTable,
+	formatJSON:  printJSON,
+	formatYAML:  printYAML,
+}
+
+func printTable(w io.Writer, value interface{}, headers []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func printJSON(w io.Writer, value interface{}, headers []string, rows [][]string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+// printYAML goes through json so the keys are the orchestrator ones
+func printYAML(w io.Writer, value interface{}, headers []string, rows [][]string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(generic); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
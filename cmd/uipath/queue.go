@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/comvex-jp/uipath-go"
+)
+
+// queueAddResult is the result of an import
+type queueAddResult struct {
+	Imported int                         `json:"imported"`
+	Failed   int                         `json:"failed"`
+	Skipped  int                         `json:"skipped"`
+	Failures []uipath.QueueImportFailure `json:"failures,omitempty"`
+}
+
+func queueAdd(env *environment, args []string) error {
+	fs := env.flags("queue add")
+	queue := fs.String("queue", "", "queue name")
+	file := fs.String("file", "", "csv, ndjson or jsonl file")
+	mapping := fs.String("mapping", "", "yaml or json column mapping, by default the Reference, Priority, DueDate and DeferDate columns are mapped to the item and the others to its specific content")
+	batchSize := fs.Int("batch-size", 100, "number of items sent per request")
+	commitType := fs.String("commit", uipath.BulkCommitProcessAllIndependently, "bulk commit type: AllOrNothing, StopOnFirstFailure or ProcessAllIndependently")
+	progress := fs.String("progress", "", "progress file, the import resumes from it when it exists")
+	failures := fs.String("failures", "", "file receiving the failed records as json lines")
+
+	if err := env.parse(fs, args, "queue", "file"); err != nil {
+		return err
+	}
+
+	importer := uipath.QueueImporter{
+		Handler:      &uipath.QueueItemHandler{Client: env.client, FolderId: env.config.FolderID},
+		Queue:        *queue,
+		Mapping:      uipath.DefaultQueueImportMapping(),
+		BatchSize:    *batchSize,
+		CommitType:   *commitType,
+		ProgressFile: *progress,
+		FailuresFile: *failures,
+		OnProgress: func(p uipath.QueueImportProgress) {
+			fmt.Fprintf(env.stderr, "line %d: %d imported, %d failed\n", p.Line, p.Imported, p.Failed)
+		},
+	}
+
+	if *mapping != "" {
+		m, err := uipath.LoadQueueImportMapping(*mapping)
+		if err != nil {
+			return usageError{err}
+		}
+
+		importer.Mapping = m
+	}
+
+	report, err := importer.ImportFile(*file)
+
+	result := queueAddResult{
+		Imported: report.Imported,
+		Failed:   report.Failed,
+		Skipped:  report.Skipped,
+		Failures: report.Failures,
+	}
+
+	var rows [][]string
+	for _, failure := range report.Failures {
+		rows = append(rows, []string{strconv.Itoa(failure.Line), failure.Reference, failure.Error})
+	}
+
+	if printErr := env.print(result, []string{"LINE", "REFERENCE", "ERROR"}, rows); printErr != nil {
+		return printErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d imported, %d failed", report.Imported, report.Failed)
+	}
+
+	fmt.Fprintf(env.stderr, "%d imported, %d skipped\n", report.Imported, report.Skipped)
+
+	return nil
+}
@@ -0,0 +1,365 @@
+package uipath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by the config loader
+const (
+	EnvProfile      = "UIPATH_PROFILE"
+	EnvConfigFile   = "UIPATH_CONFIG"
+	EnvBaseURL      = "UIPATH_BASE_URL"
+	EnvCloudURL     = "UIPATH_CLOUD_URL"
+	EnvTokenURL     = "UIPATH_TOKEN_URL"
+	EnvOrganization = "UIPATH_ORGANIZATION"
+	EnvTenant       = "UIPATH_TENANT"
+	EnvAppID        = "UIPATH_APP_ID"
+	EnvAppSecret    = "UIPATH_APP_SECRET"
+	EnvScopes       = "UIPATH_SCOPES"
+	EnvFolderID     = "UIPATH_FOLDER_ID" // the numeric id of the folder, not its name or path
+	EnvTimeout      = "UIPATH_TIMEOUT"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned when the selected profile is in none of the config files
+var ErrProfileNotFound = errors.New("uipath: profile not found")
+
+// Config defines the settings needed to build a client
+type Config struct {
+	BaseURL           string `json:"baseUrl,omitempty" yaml:"baseUrl,omitempty"`
+	CloudURL          string `json:"cloudUrl,omitempty" yaml:"cloudUrl,omitempty"`
+	TokenURL          string `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	Organization      string `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Tenant            string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	ApplicationID     string `json:"applicationId,omitempty" yaml:"applicationId,omitempty"`
+	ApplicationSecret string `json:"applicationSecret,omitempty" yaml:"applicationSecret,omitempty"`
+	Scopes            string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	FolderID          uint   `json:"folderId,omitempty" yaml:"folderId,omitempty"`
+
+	// Timeout is the http client timeout, eg. 30s
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// ProfileFile defines a config file holding several named environments
+type ProfileFile struct {
+	Default  string            `json:"default,omitempty" yaml:"default,omitempty"`
+	Profiles map[string]Config `json:"profiles" yaml:"profiles"`
+}
+
+// CLIConfig defines the config file of the UiPath CLI, usually ~/.uipath/config
+type CLIConfig struct {
+	Profiles []CLIProfile `json:"profiles" yaml:"profiles"`
+}
+
+// CLIProfile defines a profile of the UiPath CLI config
+type CLIProfile struct {
+	Name         string            `json:"name" yaml:"name"`
+	Organization string            `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Tenant       string            `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	URI          string            `json:"uri,omitempty" yaml:"uri,omitempty"`
+	Auth         CLIAuth           `json:"auth,omitempty" yaml:"auth,omitempty"`
+	Header       map[string]string `json:"header,omitempty" yaml:"header,omitempty"`
+}
+
+// CLIAuth defines the credentials of a UiPath CLI profile
+type CLIAuth struct {
+	ClientID     string `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	Scopes       string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// ConfigLoader builds a Config from, by order of precedence, the environment variables,
+// the profile file and the UiPath CLI config
+type ConfigLoader struct {
+	// Profile is the profile to load, it defaults to UIPATH_PROFILE, then to the default of the profile file
+	Profile string
+
+	// File is the profile file, it defaults to UIPATH_CONFIG and is skipped when empty
+	File string
+
+	// CLIConfigFile is the UiPath CLI config, it defaults to ~/.uipath/config and is skipped when missing
+	CLIConfigFile string
+
+	// Getenv reads the environment variables, it defaults to os.Getenv
+	Getenv func(key string) string
+}
+
+// LoadConfig loads the profile from the environment and the default config files
+func LoadConfig(profile string) (Config, error) {
+	return ConfigLoader{Profile: profile}.Load()
+}
+
+// Load builds the config
+func (l ConfigLoader) Load() (Config, error) {
+	var config Config
+
+	getenv := l.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+
+	profile := l.Profile
+	if profile == "" {
+		profile = getenv(EnvProfile)
+	}
+
+	file := l.File
+	if file == "" {
+		file = getenv(EnvConfigFile)
+	}
+
+	cliConfigFile := l.CLIConfigFile
+	explicitCLIConfig := cliConfigFile != ""
+	if !explicitCLIConfig {
+		if home, err := os.UserHomeDir(); err == nil {
+			cliConfigFile = filepath.Join(home, ".uipath", "config")
+		}
+	}
+
+	var profiles ProfileFile
+	if file != "" {
+		var err error
+
+		profiles, err = LoadProfileFile(file)
+		if err != nil {
+			return config, err
+		}
+
+		if profile == "" {
+			profile = profiles.Default
+		}
+	}
+
+	selected := profile
+	if selected == "" {
+		selected = DefaultProfile
+	}
+
+	found := false
+
+	if cliConfigFile != "" {
+		cliConfig, err := LoadCLIConfig(cliConfigFile)
+		switch {
+		case err == nil:
+			if p, ok := cliConfig.Profile(selected); ok {
+				config = config.Merge(p.Config())
+				found = true
+			}
+		case explicitCLIConfig || !errors.Is(err, os.ErrNotExist):
+			return config, err
+		}
+	}
+
+	if p, ok := profiles.Profiles[selected]; ok {
+		config = config.Merge(p)
+		found = true
+	}
+
+	if profile != "" && !found {
+		return config, fmt.Errorf("%w: %s", ErrProfileNotFound, profile)
+	}
+
+	env, err := configFromEnv(getenv)
+	if err != nil {
+		return config, err
+	}
+
+	return config.Merge(env), nil
+}
+
+// LoadProfileFile reads a yaml or json profile file
+func LoadProfileFile(path string) (ProfileFile, error) {
+	var profiles ProfileFile
+
+	err := unmarshalConfigFile(path, &profiles)
+
+	return profiles, err
+}
+
+// LoadCLIConfig reads the UiPath CLI config, which is yaml
+func LoadCLIConfig(path string) (CLIConfig, error) {
+	var config CLIConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+
+	err = yaml.Unmarshal(data, &config)
+
+	return config, err
+}
+
+// Profile returns the profile with the given name
+func (c CLIConfig) Profile(name string) (CLIProfile, bool) {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return CLIProfile{}, false
+}
+
+// Config converts the UiPath CLI profile to a client config
+func (p CLIProfile) Config() Config {
+	config := Config{
+		CloudURL:          p.URI,
+		Organization:      p.Organization,
+		Tenant:            p.Tenant,
+		ApplicationID:     p.Auth.ClientID,
+		ApplicationSecret: p.Auth.ClientSecret,
+		Scopes:            p.Auth.Scopes,
+	}
+
+	for k, v := range p.Header {
+		if strings.EqualFold(k, HeaderOrganizationUnitId) {
+			if folderID, err := strconv.ParseUint(v, 10, 0); err == nil {
+				config.FolderID = uint(folderID)
+			}
+		}
+	}
+
+	if p.URI != "" {
+		config.TokenURL = cloudTokenURL(p.URI)
+	}
+
+	return config
+}
+
+// Merge returns the config overridden by the non empty values of other
+func (c Config) Merge(other Config) Config {
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+
+	override(&c.BaseURL, other.BaseURL)
+	override(&c.CloudURL, other.CloudURL)
+	override(&c.TokenURL, other.TokenURL)
+	override(&c.Organization, other.Organization)
+	override(&c.Tenant, other.Tenant)
+	override(&c.ApplicationID, other.ApplicationID)
+	override(&c.ApplicationSecret, other.ApplicationSecret)
+	override(&c.Scopes, other.Scopes)
+	override(&c.Timeout, other.Timeout)
+
+	if other.FolderID != 0 {
+		c.FolderID = other.FolderID
+	}
+
+	return c
+}
+
+// Options converts the config to NewClient options
+func (c Config) Options() ([]Option, error) {
+	opts := []Option{
+		WithApplicationCredentials(c.ApplicationID, c.ApplicationSecret, c.Scopes),
+		WithOrganization(c.Organization, c.Tenant),
+	}
+
+	if c.BaseURL != "" {
+		opts = append(opts, WithBaseURL(c.BaseURL))
+	}
+
+	if c.CloudURL != "" {
+		opts = append(opts, WithCloudURL(c.CloudURL))
+	}
+
+	if c.TokenURL != "" {
+		opts = append(opts, WithTokenURL(c.TokenURL))
+	}
+
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", c.Timeout, err)
+		}
+
+		opts = append(opts, WithTimeout(timeout))
+	}
+
+	return opts, nil
+}
+
+// NewClient builds a client from the config, opts are applied after the config ones
+func (c Config) NewClient(opts ...Option) (*Client, error) {
+	configOpts, err := c.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(append(configOpts, opts...)...)
+}
+
+// Redacted returns a copy of the config without its secret, safe to print
+func (c Config) Redacted() Config {
+	c.ApplicationSecret = redact(c.ApplicationSecret)
+
+	return c
+}
+
+// String prints the config with its secret redacted
+func (c Config) String() string {
+	type config Config
+
+	return fmt.Sprintf("%+v", config(c.Redacted()))
+}
+
+// GoString prints the config with its secret redacted
+func (c Config) GoString() string {
+	return "uipath.Config" + c.String()
+}
+
+func configFromEnv(getenv func(string) string) (Config, error) {
+	config := Config{
+		BaseURL:           getenv(EnvBaseURL),
+		CloudURL:          getenv(EnvCloudURL),
+		TokenURL:          getenv(EnvTokenURL),
+		Organization:      getenv(EnvOrganization),
+		Tenant:            getenv(EnvTenant),
+		ApplicationID:     getenv(EnvAppID),
+		ApplicationSecret: getenv(EnvAppSecret),
+		Scopes:            getenv(EnvScopes),
+		Timeout:           getenv(EnvTimeout),
+	}
+
+	if folder := getenv(EnvFolderID); folder != "" {
+		folderID, err := strconv.ParseUint(folder, 10, 0)
+		if err != nil {
+			return config, fmt.Errorf("invalid %s %q, it takes a numeric folder id: %w", EnvFolderID, folder, err)
+		}
+
+		config.FolderID = uint(folderID)
+	}
+
+	return config, nil
+}
+
+func unmarshalConfigFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("unsupported config format: %s", path)
+	}
+}
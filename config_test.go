@@ -0,0 +1,140 @@
+package uipath
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCLIConfig = `profiles:
+  - name: default
+    organization: cliOrg
+    tenant: cliTenant
+    auth:
+      clientId: cliAppID
+      clientSecret: cliSecret
+  - name: staging
+    organization: stagingOrg
+    tenant: stagingTenant
+    uri: https://staging.uipath.com/
+    auth:
+      clientId: stagingAppID
+      clientSecret: stagingSecret
+      scopes: OR.Queues
+    header:
+      X-UIPATH-OrganizationUnitId: "42"
+`
+
+const testProfileFile = `{
+  "default": "dev",
+  "profiles": {
+    "dev": {"organization": "devOrg", "tenant": "devTenant", "applicationId": "devAppID", "applicationSecret": "devSecret", "folderId": 7},
+    "staging": {"tenant": "fileTenant", "timeout": "10s"}
+  }
+}`
+
+func writeConfigFiles(t *testing.T) (string, string) {
+	dir := t.TempDir()
+
+	cliConfig := filepath.Join(dir, "config")
+	profileFile := filepath.Join(dir, "profiles.json")
+
+	assert.Nil(t, os.WriteFile(cliConfig, []byte(testCLIConfig), 0600))
+	assert.Nil(t, os.WriteFile(profileFile, []byte(testProfileFile), 0600))
+
+	return cliConfig, profileFile
+}
+
+func envMap(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestConfigLoaderPrecedence(t *testing.T) {
+	cliConfig, profileFile := writeConfigFiles(t)
+
+	config, err := ConfigLoader{
+		Profile:       "staging",
+		File:          profileFile,
+		CLIConfigFile: cliConfig,
+		Getenv:        envMap(map[string]string{EnvAppSecret: "envSecret"}),
+	}.Load()
+
+	assert.Nil(t, err)
+	assert.Equal(t, Config{
+		CloudURL:          "https://staging.uipath.com/",
+		TokenURL:          "https://staging.uipath.com/identity_/connect/token",
+		Organization:      "stagingOrg",
+		Tenant:            "fileTenant",
+		ApplicationID:     "stagingAppID",
+		ApplicationSecret: "envSecret",
+		Scopes:            "OR.Queues",
+		FolderID:          42,
+		Timeout:           "10s",
+	}, config)
+}
+
+func TestConfigLoaderDefaultProfile(t *testing.T) {
+	cliConfig, profileFile := writeConfigFiles(t)
+
+	config, err := ConfigLoader{
+		CLIConfigFile: cliConfig,
+		Getenv:        envMap(map[string]string{EnvConfigFile: profileFile, EnvFolderID: "9"}),
+	}.Load()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "devOrg", config.Organization)
+	assert.Equal(t, "devAppID", config.ApplicationID)
+	assert.Equal(t, uint(9), config.FolderID)
+
+	config, err = ConfigLoader{CLIConfigFile: cliConfig, Getenv: envMap(nil)}.Load()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "cliOrg", config.Organization)
+
+	_, err = ConfigLoader{Profile: "prod", CLIConfigFile: cliConfig, Getenv: envMap(nil)}.Load()
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestConfigLoaderFromEnv(t *testing.T) {
+	env := envMap(map[string]string{
+		EnvBaseURL:   "https://orchestrator.example.com/org/tenant/orchestrator_/",
+		EnvTenant:    "tenant",
+		EnvAppID:     "appID",
+		EnvAppSecret: "appSecret",
+	})
+
+	// An explicit UiPath CLI config must exist
+	_, err := ConfigLoader{CLIConfigFile: filepath.Join(t.TempDir(), "config"), Getenv: env}.Load()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// The default one is skipped when missing
+	t.Setenv("HOME", t.TempDir())
+
+	config, err := ConfigLoader{Getenv: env}.Load()
+	assert.Nil(t, err)
+
+	c, err := config.NewClient()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://orchestrator.example.com/org/tenant/orchestrator_/odata/", c.BaseURL)
+	assert.Equal(t, "tenant", c.Credentials.TenantName)
+	assert.Equal(t, "appSecret", c.Credentials.ApplicationSecret)
+
+	_, err = ConfigLoader{Getenv: envMap(map[string]string{EnvFolderID: "Shared/Finance"})}.Load()
+	assert.ErrorContains(t, err, "numeric folder id")
+}
+
+func TestConfigRedactsSecret(t *testing.T) {
+	config := Config{Organization: "org", ApplicationID: "appID", ApplicationSecret: "appSecret"}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		printed := fmt.Sprintf(format, config)
+
+		assert.NotContains(t, printed, "appSecret")
+		assert.Contains(t, printed, RedactedValue)
+		assert.Contains(t, printed, "appID")
+	}
+}
@@ -0,0 +1,147 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	CredentialStoreTypeDatabase       = "Database"
+	CredentialStoreTypeCyberArk       = "CyberArk"
+	CredentialStoreTypeCyberArkCCP    = "CyberArkCCP"
+	CredentialStoreTypeAzureKeyVault  = "AzureKeyVault"
+	CredentialStoreTypeHashicorpVault = "HashicorpVault"
+
+	CredentialStoreResourceTypeAsset = "AssetCredential"
+	CredentialStoreResourceTypeRobot = "RobotCredential"
+
+	CredentialStoreEndpoint        = "CredentialStores"
+	CredentialStoreDefaultEndpoint = "CredentialStores/UiPath.Server.Configuration.OData.GetDefaultStoreForResourceType"
+)
+
+// CredentialStoreHandler struct defines what the credential store handler looks like
+type CredentialStoreHandler struct {
+	Client   *Client
+	FolderId uint
+}
+
+// CredentialStore struct defines what the credential store model looks like
+type CredentialStore struct {
+	ID                      uint   `json:"Id,omitempty"`
+	Name                    string `json:"Name"`
+	Type                    string `json:"Type"`
+	ProxyID                 *uint  `json:"ProxyId,omitempty"`
+	HostName                string `json:"HostName,omitempty"`
+	AdditionalConfiguration string `json:"AdditionalConfiguration,omitempty"`
+	IsReadOnly              bool   `json:"IsReadOnly,omitempty"`
+	CreationTime            string `json:"CreationTime,omitempty"`
+}
+
+// CredentialStoreList struct defines what the credential store list looks like
+type CredentialStoreList struct {
+	Count int               `json:"@odata.count"`
+	Value []CredentialStore `json:"value"`
+}
+
+type credentialStoreDefault struct {
+	Value uint `json:"value"`
+}
+
+type credentialStoreAlias CredentialStore
+
+// String prints the credential store without its additional configuration since it contains the store secrets
+func (c CredentialStore) String() string {
+	alias := credentialStoreAlias(c)
+	alias.AdditionalConfiguration = redact(alias.AdditionalConfiguration)
+
+	return fmt.Sprintf("%+v", alias)
+}
+
+// GetByID fetches the credential store by id
+func (c *CredentialStoreHandler) GetByID(ID uint) (CredentialStore, error) {
+	var store CredentialStore
+
+	url := fmt.Sprintf("%s%s(%d)", c.Client.BaseURL, CredentialStoreEndpoint, ID)
+
+	resp, err := c.Client.SendWithAuthorization("GET", url, nil, c.buildHeaders(), map[string]string{})
+	if err != nil {
+		return store, err
+	}
+
+	err = json.Unmarshal(resp, &store)
+
+	return store, err
+}
+
+// List fetches a list of credential stores that can be filtered using query parameters
+func (c *CredentialStoreHandler) List(filters map[string]string) ([]CredentialStore, int, error) {
+	var storeList CredentialStoreList
+
+	url := fmt.Sprintf("%s%s", c.Client.BaseURL, CredentialStoreEndpoint)
+
+	resp, err := c.Client.SendWithAuthorization("GET", url, nil, c.buildHeaders(), filters)
+	if err != nil {
+		return storeList.Value, storeList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &storeList)
+
+	return storeList.Value, storeList.Count, err
+}
+
+// Store creates a credential store on the orchestrator
+func (c *CredentialStoreHandler) Store(store CredentialStore) (CredentialStore, error) {
+	var result CredentialStore
+
+	url := fmt.Sprintf("%s%s", c.Client.BaseURL, CredentialStoreEndpoint)
+
+	resp, err := c.Client.SendWithAuthorization("POST", url, store, c.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result, err
+}
+
+// Update updates a credential store
+func (c *CredentialStoreHandler) Update(store CredentialStore) (CredentialStore, error) {
+	url := fmt.Sprintf("%s%s(%d)", c.Client.BaseURL, CredentialStoreEndpoint, store.ID)
+
+	_, err := c.Client.SendWithAuthorization("PUT", url, store, c.buildHeaders(), map[string]string{})
+	if err != nil {
+		return store, err
+	}
+
+	return c.GetByID(store.ID)
+}
+
+// DeleteByID deletes a credential store by id
+func (c *CredentialStoreHandler) DeleteByID(ID uint) error {
+	url := fmt.Sprintf("%s%s(%d)", c.Client.BaseURL, CredentialStoreEndpoint, ID)
+
+	_, err := c.Client.SendWithAuthorization("DELETE", url, nil, c.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// GetDefaultID fetches the id of the default credential store for a resource type
+func (c *CredentialStoreHandler) GetDefaultID(resourceType string) (uint, error) {
+	var result credentialStoreDefault
+
+	url := fmt.Sprintf("%s%s(resourceType='%s')", c.Client.BaseURL, CredentialStoreDefaultEndpoint, resourceType)
+
+	resp, err := c.Client.SendWithAuthorization("GET", url, nil, c.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result.Value, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result.Value, err
+}
+
+func (c *CredentialStoreHandler) buildHeaders() map[string]string {
+	return tenantHeaders(c.FolderId)
+}
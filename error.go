@@ -13,6 +13,18 @@ const (
 
 	// Asset does not exist
 	ItemNotFoundCode = 1002
+
+	// Queue item with the same reference already exists
+	DuplicateReferenceCode = 1016
+)
+
+// Sentinel errors that can be matched with errors.Is against the errors returned by the client
+var (
+	ErrNotFound           = errors.New("uipath: not found")
+	ErrUnauthorized       = errors.New("uipath: unauthorized")
+	ErrConflict           = errors.New("uipath: conflict")
+	ErrRateLimited        = errors.New("uipath: rate limited")
+	ErrDuplicateReference = errors.New("uipath: duplicate reference")
 )
 
 // RequestError defines how the error looks like from the response
@@ -25,6 +37,22 @@ type RequestError struct {
 	ResourceIds      []uint `json:"resourceIds"`
 }
 
+// APIError defines the error returned when the orchestrator answers with an unsuccessful status
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	ErrorCode  int
+	TraceID    string
+	Message    string
+
+	// Body is the raw response body, kept even when it is not json (eg. html from a proxy)
+	Body []byte
+
+	// RequestError is the decoded orchestrator error, nil when the body did not contain one
+	RequestError *RequestError
+}
+
 func (r *RequestError) Error() string {
 	if r.ErrorName != "" {
 		return fmt.Sprintf("Request Failed: Error Code(%s) %s", r.ErrorName, r.ErrorDescription)
@@ -33,22 +61,84 @@ func (r *RequestError) Error() string {
 	return fmt.Sprintf("Request Failed: Error Code(%d) %s", r.ErrorCode, r.Message)
 }
 
+func (r *RequestError) isEmpty() bool {
+	return r.Message == "" && r.ErrorName == "" && r.ErrorCode == 0 && r.ErrorDescription == ""
+}
+
+func (e *APIError) Error() string {
+	if e.RequestError != nil {
+		return e.RequestError.Error()
+	}
+
+	return fmt.Sprintf("HTTP Error %d: %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Unwrap gives access to the decoded orchestrator error through errors.As
+func (e *APIError) Unwrap() error {
+	if e.RequestError == nil {
+		return nil
+	}
+
+	return e.RequestError
+}
+
+// Is matches the error against the sentinel errors and the error categories
+func (e *APIError) Is(target error) bool {
+	if category, ok := target.(ErrorCategory); ok {
+		return e.Category() == category
+	}
+
+	switch target {
+	case ErrNotFound:
+		return e.Category() == ErrorCategoryNotFound
+	case ErrUnauthorized:
+		return e.Category() == ErrorCategoryUnauthorized
+	case ErrConflict:
+		return e.Category() == ErrorCategoryConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrDuplicateReference:
+		return e.ErrorCode == DuplicateReferenceCode
+	}
+
+	return false
+}
+
 // ErrorResponseHandler handles the errors from the uipath response
 func ErrorResponseHandler(statusCode int, errResp []byte) error {
+	return newAPIError(statusCode, errResp)
+}
+
+func newAPIError(statusCode int, errResp []byte) *APIError {
 	var requestError RequestError
 
+	apiError := &APIError{
+		StatusCode: statusCode,
+		Body:       errResp,
+	}
+
 	// Check the response body if it's empty and if it is, we assume that it's an HTTP error.
 	if len(errResp) < 1 {
-		return errors.New(fmt.Sprintf("HTTP Error %d: %s", statusCode, http.StatusText(statusCode)))
+		return apiError
 	}
 
-	if err := json.Unmarshal(errResp, &requestError); err != nil {
-		return err
+	// Bodies that are not orchestrator errors are kept as they are
+	if err := json.Unmarshal(errResp, &requestError); err != nil || requestError.isEmpty() {
+		apiError.Message = string(errResp)
+		return apiError
 	}
 
 	if requestError.ErrorCode == 0 && requestError.ErrorDescription == "Unauthorized" {
 		requestError.ErrorCode = UnauthorizedCode
 	}
 
-	return &requestError
+	apiError.RequestError = &requestError
+	apiError.ErrorCode = requestError.ErrorCode
+	apiError.TraceID = requestError.TraceIDs
+	apiError.Message = requestError.Message
+	if apiError.Message == "" {
+		apiError.Message = requestError.ErrorDescription
+	}
+
+	return apiError
 }
@@ -0,0 +1,147 @@
+package uipath
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// Orchestrator errorCode values returned in the body of unsuccessful responses
+const (
+	ErrorCodeInvalidRequest          = 1000
+	ErrorCodeItemNotFound            = ItemNotFoundCode
+	ErrorCodeNameAlreadyExists       = 1004
+	ErrorCodeDuplicateReference      = DuplicateReferenceCode
+	ErrorCodeInvalidTransactionState = 1018
+	ErrorCodeFolderNotFound          = 1100
+	ErrorCodeFolderRequired          = 1101
+	ErrorCodeInvalidRobot            = 1602
+	ErrorCodeRobotNotConnected       = 1603
+	ErrorCodeLicenseExhausted        = 1607
+	ErrorCodeLicenseNotAvailable     = 1608
+	ErrorCodeUnauthorized            = UnauthorizedCode
+)
+
+// ErrorCategory groups errors by meaning, categories can be matched with errors.Is
+type ErrorCategory string
+
+const (
+	ErrorCategoryUnknown      ErrorCategory = "unknown"
+	ErrorCategoryValidation   ErrorCategory = "validation"
+	ErrorCategoryUnauthorized ErrorCategory = "unauthorized"
+	ErrorCategoryForbidden    ErrorCategory = "forbidden"
+	ErrorCategoryNotFound     ErrorCategory = "not found"
+	ErrorCategoryConflict     ErrorCategory = "conflict"
+	ErrorCategoryLicense      ErrorCategory = "license"
+	ErrorCategoryRobot        ErrorCategory = "robot"
+	ErrorCategoryRateLimited  ErrorCategory = "rate limited"
+	ErrorCategoryTransient    ErrorCategory = "transient"
+	ErrorCategoryNetwork      ErrorCategory = "network"
+	ErrorCategoryCanceled     ErrorCategory = "canceled"
+)
+
+// errorCodeCategories maps the orchestrator error codes to their category
+var errorCodeCategories = map[int]ErrorCategory{
+	ErrorCodeInvalidRequest:          ErrorCategoryValidation,
+	ErrorCodeItemNotFound:            ErrorCategoryNotFound,
+	ErrorCodeNameAlreadyExists:       ErrorCategoryConflict,
+	ErrorCodeDuplicateReference:      ErrorCategoryConflict,
+	ErrorCodeInvalidTransactionState: ErrorCategoryConflict,
+	ErrorCodeFolderNotFound:          ErrorCategoryNotFound,
+	ErrorCodeFolderRequired:          ErrorCategoryValidation,
+	ErrorCodeInvalidRobot:            ErrorCategoryRobot,
+	ErrorCodeRobotNotConnected:       ErrorCategoryRobot,
+	ErrorCodeLicenseExhausted:        ErrorCategoryLicense,
+	ErrorCodeLicenseNotAvailable:     ErrorCategoryLicense,
+	ErrorCodeUnauthorized:            ErrorCategoryUnauthorized,
+}
+
+func (c ErrorCategory) Error() string {
+	return "uipath: " + string(c)
+}
+
+// Category returns the category of the error from its orchestrator error code, falling back to its status code
+func (e *APIError) Category() ErrorCategory {
+	if category, ok := errorCodeCategories[e.ErrorCode]; ok {
+		return category
+	}
+
+	switch {
+	case e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrorCategoryValidation
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrorCategoryUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrorCategoryForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrorCategoryNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrorCategoryConflict
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimited
+	case e.StatusCode == http.StatusRequestTimeout || e.StatusCode >= http.StatusInternalServerError:
+		return ErrorCategoryTransient
+	}
+
+	return ErrorCategoryUnknown
+}
+
+// canceledError is the error of a request stopped because the context of the caller was done, the
+// http client timeouts also match context.DeadlineExceeded but they are network errors
+type canceledError struct {
+	err error
+}
+
+func (e *canceledError) Error() string {
+	return e.err.Error()
+}
+
+func (e *canceledError) Unwrap() error {
+	return e.err
+}
+
+// CategoryOf returns the category of any error returned by the client
+func CategoryOf(err error) ErrorCategory {
+	var apiError *APIError
+	var canceled *canceledError
+	var urlError *url.Error
+	var netError net.Error
+
+	switch {
+	case err == nil:
+		return ErrorCategoryUnknown
+	case errors.As(err, &canceled) || errors.Is(err, context.Canceled):
+		return ErrorCategoryCanceled
+	case errors.As(err, &apiError):
+		return apiError.Category()
+	case errors.As(err, &urlError):
+		return ErrorCategoryNetwork
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCategoryCanceled
+	case errors.As(err, &netError):
+		return ErrorCategoryNetwork
+	}
+
+	return ErrorCategoryUnknown
+}
+
+// requestError marks the error of a request whose context is done as canceled
+func requestError(req *http.Request, err error) error {
+	if req.Context().Err() != nil {
+		return &canceledError{err: err}
+	}
+
+	return err
+}
+
+// IsRetryable checks if the request that failed with err can be sent again
+func IsRetryable(err error) bool {
+	switch CategoryOf(err) {
+	case ErrorCategoryRateLimited, ErrorCategoryTransient, ErrorCategoryNetwork:
+		return true
+	}
+
+	return false
+}
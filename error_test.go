@@ -0,0 +1,111 @@
+package uipath
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorResponseHandlerOrchestratorError(t *testing.T) {
+	err := ErrorResponseHandler(http.StatusNotFound, []byte(`{"message":"Asset does not exist.","errorCode":1002,"traceId":"00-abc-01"}`))
+
+	var apiError *APIError
+	var requestError *RequestError
+
+	assert.True(t, errors.As(err, &apiError))
+	assert.True(t, errors.As(err, &requestError))
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.False(t, errors.Is(err, ErrConflict))
+	assert.Equal(t, "00-abc-01", apiError.TraceID)
+	assert.Equal(t, ItemNotFoundCode, apiError.ErrorCode)
+	assert.Equal(t, "Request Failed: Error Code(1002) Asset does not exist.", err.Error())
+}
+
+func TestErrorResponseHandlerKeepsNonJSONBody(t *testing.T) {
+	body := []byte("<html><body>502 Bad Gateway</body></html>")
+
+	err := ErrorResponseHandler(http.StatusBadGateway, body)
+
+	var apiError *APIError
+
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, body, apiError.Body)
+	assert.Nil(t, apiError.RequestError)
+	assert.Equal(t, "HTTP Error 502: Bad Gateway", err.Error())
+}
+
+func TestSendReturnsAPIErrorWithRequestMetadata(t *testing.T) {
+	c := Client{
+		HttpClient: &httpClientMock{
+			MockedDo: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"message":"Error creating Transaction. Duplicate Reference.","errorCode":1016}`))),
+				}, nil
+			},
+		},
+	}
+
+	_, err := c.Send("POST", "https://example.com/odata/Queues", nil, map[string]string{}, nil)
+
+	var apiError *APIError
+
+	assert.True(t, errors.As(err, &apiError))
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.ErrorIs(t, err, ErrDuplicateReference)
+	assert.Equal(t, "POST", apiError.Method)
+	assert.Equal(t, "https://example.com/odata/Queues", apiError.URL)
+}
+
+func TestErrorCategories(t *testing.T) {
+	folderNotFound := ErrorResponseHandler(http.StatusBadRequest, []byte(`{"message":"Folder does not exist or the user does not have access to the folder.","errorCode":1100}`))
+	licenseExhausted := ErrorResponseHandler(http.StatusBadRequest, []byte(`{"message":"No license available.","errorCode":1607}`))
+	unavailable := ErrorResponseHandler(http.StatusServiceUnavailable, nil)
+	rateLimited := ErrorResponseHandler(http.StatusTooManyRequests, nil)
+
+	assert.Equal(t, ErrorCategoryNotFound, CategoryOf(folderNotFound))
+	assert.ErrorIs(t, folderNotFound, ErrNotFound)
+	assert.ErrorIs(t, licenseExhausted, ErrorCategoryLicense)
+	assert.False(t, IsRetryable(licenseExhausted))
+	assert.True(t, IsRetryable(unavailable))
+	assert.True(t, IsRetryable(rateLimited))
+	assert.True(t, IsRetryable(&net.DNSError{Err: "no such host", IsTimeout: true}))
+	assert.False(t, IsRetryable(context.Canceled))
+}
+
+func TestErrorCategoriesOfTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &Client{HttpClient: &http.Client{Timeout: 20 * time.Millisecond}}
+
+	_, err := client.Send("GET", server.URL, nil, map[string]string{}, nil)
+	assert.Equal(t, ErrorCategoryNetwork, CategoryOf(err))
+	assert.True(t, IsRetryable(err))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	client = &Client{HttpClient: &http.Client{}}
+
+	_, err = client.WithContext(ctx).Send("GET", server.URL, nil, map[string]string{}, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, ErrorCategoryCanceled, CategoryOf(err))
+	assert.False(t, IsRetryable(err))
+	assert.False(t, IsRetryable(context.DeadlineExceeded))
+}
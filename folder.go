@@ -0,0 +1,21 @@
+package uipath
+
+const (
+	FolderTypeStandard = "Standard"
+	FolderTypePersonal = "Personal"
+	FolderTypeVirtual  = "Virtual"
+)
+
+// Folder struct defines what the folder model looks like
+type Folder struct {
+	ID                 uint   `json:"Id,omitempty"`
+	Key                string `json:"Key,omitempty"`
+	DisplayName        string `json:"DisplayName"`
+	FullyQualifiedName string `json:"FullyQualifiedName,omitempty"`
+	Description        string `json:"Description,omitempty"`
+	FolderType         string `json:"FolderType,omitempty"`
+	ProvisionType      string `json:"ProvisionType,omitempty"`
+	PermissionModel    string `json:"PermissionModel,omitempty"`
+	ParentID           *uint  `json:"ParentId,omitempty"`
+	ParentKey          string `json:"ParentKey,omitempty"`
+}
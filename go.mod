@@ -2,12 +2,15 @@ module github.com/comvex-jp/uipath-go
 
 go 1.18
 
-require github.com/stretchr/testify v1.7.1
+require (
+	github.com/google/uuid v1.6.0
+	github.com/jarcoal/httpmock v1.1.0
+	github.com/patrickmn/go-cache v2.1.0+incompatible
+	github.com/stretchr/testify v1.7.1
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/jarcoal/httpmock v1.1.0 // indirect
-	github.com/patrickmn/go-cache v2.1.0+incompatible // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
@@ -0,0 +1,109 @@
+package uipath
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Instrumentation receives the events of the client so that tracing and metrics, such as OpenTelemetry,
+// can be plugged in without the client depending on them
+type Instrumentation interface {
+	// StartRequest is called before every api call. The returned request is the one sent, which lets the
+	// implementation start a span from req.Context() and inject the trace context into the request headers.
+	StartRequest(req *http.Request, info RequestInfo) (*http.Request, RequestSpan)
+
+	// RecordRetry is called before an api call is sent again
+	RecordRetry(info RequestInfo, attempt int, err error)
+
+	// RecordTokenRefresh is called after fetching a new access token
+	RecordTokenRefresh(duration time.Duration, err error)
+}
+
+// RequestSpan is ended once the response of the api call is read
+type RequestSpan interface {
+	End(result RequestResult)
+}
+
+// RequestInfo describes an api call
+type RequestInfo struct {
+	Method string
+
+	// Endpoint is the endpoint template with the keys replaced, eg. Assets({key})
+	Endpoint string
+	FolderID uint
+}
+
+// RequestResult describes the outcome of an api call
+type RequestResult struct {
+	StatusCode int
+	Duration   time.Duration
+
+	// ErrorCode and TraceID come from the orchestrator error
+	ErrorCode int
+	TraceID   string
+	Err       error
+}
+
+type noopInstrumentation struct{}
+
+type noopSpan struct{}
+
+var endpointKeyPattern = regexp.MustCompile(`\([^)]*\)`)
+
+func (noopInstrumentation) StartRequest(req *http.Request, info RequestInfo) (*http.Request, RequestSpan) {
+	return req, noopSpan{}
+}
+
+func (noopInstrumentation) RecordRetry(info RequestInfo, attempt int, err error) {}
+
+func (noopInstrumentation) RecordTokenRefresh(duration time.Duration, err error) {}
+
+func (noopSpan) End(result RequestResult) {}
+
+// instrumentation returns the configured instrumentation or one ignoring everything
+func (client *Client) instrumentation() Instrumentation {
+	if client.Instrumentation == nil {
+		return noopInstrumentation{}
+	}
+
+	return client.Instrumentation
+}
+
+// requestInfo describes the request with its endpoint template and folder
+func (client *Client) requestInfo(req *http.Request) RequestInfo {
+	folderID, _ := strconv.Atoi(req.Header.Get(HeaderOrganizationUnitId))
+
+	return RequestInfo{
+		Method:   req.Method,
+		Endpoint: client.endpointTemplate(req.URL),
+		FolderID: uint(folderID),
+	}
+}
+
+// endpointTemplate strips the base url and replaces the keys and function parameters so that
+// requests to the same endpoint share the same name
+func (client *Client) endpointTemplate(u *url.URL) string {
+	endpoint := u.Scheme + "://" + u.Host + u.Path
+
+	for _, base := range []string{client.BaseURL, client.rootURL()} {
+		if base != "" && strings.HasPrefix(endpoint, base) {
+			endpoint = strings.TrimPrefix(endpoint, base)
+			break
+		}
+	}
+
+	if strings.Contains(endpoint, "://") {
+		endpoint = strings.TrimPrefix(u.Path, "/")
+	}
+
+	unescaped, err := url.PathUnescape(endpoint)
+	if err == nil {
+		endpoint = unescaped
+	}
+
+	return endpointKeyPattern.ReplaceAllString(endpoint, "({key})")
+}
@@ -0,0 +1,166 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	JobEndpoint      = "Jobs"
+	JobStartEndpoint = "Jobs/UiPathODataSvc.StartJobs"
+
+	JobStrategyModernJobsCount = "ModernJobsCount"
+	JobStrategySpecific        = "Specific"
+
+	JobStatePending     = "Pending"
+	JobStateRunning     = "Running"
+	JobStateStopping    = "Stopping"
+	JobStateTerminating = "Terminating"
+	JobStateFaulted     = "Faulted"
+	JobStateSuccessful  = "Successful"
+	JobStateStopped     = "Stopped"
+	JobStateSuspended   = "Suspended"
+	JobStateResumed     = "Resumed"
+)
+
+// Job struct defines what the job model looks like
+type Job struct {
+	ID                                 uint   `json:"Id,omitempty"`
+	Key                                string `json:"Key,omitempty"`
+	StartTime                          string `json:"StartTime,omitempty"`
+	EndTime                            string `json:"EndTime,omitempty"`
+	State                              string `json:"State,omitempty"`
+	JobPriority                        string `json:"JobPriority,omitempty"`
+	Source                             string `json:"Source,omitempty"`
+	SourceType                         string `json:"SourceType,omitempty"`
+	BatchExecutionKey                  string `json:"BatchExecutionKey,omitempty"`
+	Info                               string `json:"Info,omitempty"`
+	CreationTime                       string `json:"CreationTime,omitempty"`
+	ReleaseName                        string `json:"ReleaseName,omitempty"`
+	Type                               string `json:"Type,omitempty"`
+	InputArguments                     string `json:"InputArguments,omitempty"`
+	OutputArguments                    string `json:"OutputArguments,omitempty"`
+	HostMachineName                    string `json:"HostMachineName,omitempty"`
+	Reference                          string `json:"Reference,omitempty"`
+	OrganizationUnitID                 uint   `json:"OrganizationUnitId,omitempty"`
+	OrganizationUnitFullyQualifiedName string `json:"OrganizationUnitFullyQualifiedName,omitempty"`
+}
+
+// JobHandler struct defines what the job handler looks like
+type JobHandler struct {
+	Client   *Client
+	FolderId uint
+}
+
+// JobList defines what the job list model looks like
+type JobList struct {
+	Count int   `json:"@odata.count"`
+	Value []Job `json:"value"`
+}
+
+// StartJobsInfo defines which release to start and how
+type StartJobsInfo struct {
+	ReleaseKey     string `json:"ReleaseKey"`
+	Strategy       string `json:"Strategy,omitempty"`
+	JobsCount      int    `json:"JobsCount,omitempty"`
+	RobotIds       []uint `json:"RobotIds,omitempty"`
+	JobPriority    string `json:"JobPriority,omitempty"`
+	Source         string `json:"Source,omitempty"`
+	InputArguments string `json:"InputArguments,omitempty"`
+	Reference      string `json:"Reference,omitempty"`
+}
+
+// StartJobsRequest defines how the request looks like when starting jobs
+type StartJobsRequest struct {
+	StartInfo StartJobsInfo `json:"startInfo"`
+}
+
+// IsFinal checks if the job reached a state it will not leave
+func (j Job) IsFinal() bool {
+	switch j.State {
+	case JobStateFaulted, JobStateSuccessful, JobStateStopped:
+		return true
+	}
+
+	return false
+}
+
+// GetByID fetches a job by id
+func (j *JobHandler) GetByID(ID uint) (Job, error) {
+	var job Job
+
+	url := fmt.Sprintf("%s%s(%d)", j.Client.BaseURL, JobEndpoint, ID)
+
+	resp, err := j.Client.SendWithAuthorization("GET", url, nil, j.buildHeaders(), map[string]string{})
+	if err != nil {
+		return job, err
+	}
+
+	err = json.Unmarshal(resp, &job)
+
+	return job, err
+}
+
+// List fetches a list of jobs that can be filtered using query parameters
+func (j *JobHandler) List(filters map[string]string) ([]Job, int, error) {
+	var jobList JobList
+
+	url := fmt.Sprintf("%s%s", j.Client.BaseURL, JobEndpoint)
+
+	resp, err := j.Client.SendWithAuthorization("GET", url, nil, j.buildHeaders(), filters)
+	if err != nil {
+		return jobList.Value, jobList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &jobList)
+
+	return jobList.Value, jobList.Count, err
+}
+
+// Start starts jobs for a release, the strategy defaults to ModernJobsCount with a single job
+func (j *JobHandler) Start(startInfo StartJobsInfo) ([]Job, error) {
+	var jobList JobList
+
+	if startInfo.Strategy == "" {
+		startInfo.Strategy = JobStrategyModernJobsCount
+	}
+
+	if startInfo.Strategy == JobStrategyModernJobsCount && startInfo.JobsCount == 0 {
+		startInfo.JobsCount = 1
+	}
+
+	url := fmt.Sprintf("%s%s", j.Client.BaseURL, JobStartEndpoint)
+
+	resp, err := j.Client.SendWithAuthorization("POST", url, StartJobsRequest{StartInfo: startInfo}, j.buildHeaders(), map[string]string{})
+	if err != nil {
+		return jobList.Value, err
+	}
+
+	err = json.Unmarshal(resp, &jobList)
+
+	return jobList.Value, err
+}
+
+// Wait polls the job until it reaches a final state, it stops with the client context
+func (j *JobHandler) Wait(ID uint, interval time.Duration) (Job, error) {
+	for {
+		job, err := j.GetByID(ID)
+		if err != nil || job.IsFinal() {
+			return job, err
+		}
+
+		if err := sleepContext(j.Client.Context(), interval); err != nil {
+			return job, err
+		}
+	}
+}
+
+func (j *JobHandler) buildHeaders() map[string]string {
+	var headers = map[string]string{}
+
+	headers[HeaderOrganizationUnitId] = strconv.Itoa(int(j.FolderId))
+
+	return headers
+}
@@ -0,0 +1,79 @@
+package uipath
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger is the structured logger used by the client, a *slog.Logger satisfies it.
+// The args are alternating keys and values.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// StdLogger adapts a standard library *log.Logger to the Logger interface
+type StdLogger struct {
+	Logger *log.Logger
+
+	// Verbose enables the debug messages, which include every request and response
+	Verbose bool
+}
+
+type noopLogger struct{}
+
+func (l StdLogger) Debug(msg string, args ...interface{}) {
+	if l.Verbose {
+		l.print("DEBUG", msg, args)
+	}
+}
+
+func (l StdLogger) Info(msg string, args ...interface{}) {
+	l.print("INFO", msg, args)
+}
+
+func (l StdLogger) Warn(msg string, args ...interface{}) {
+	l.print("WARN", msg, args)
+}
+
+func (l StdLogger) Error(msg string, args ...interface{}) {
+	l.print("ERROR", msg, args)
+}
+
+func (l StdLogger) print(level string, msg string, args []interface{}) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s", level, msg)
+
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", args[i])
+		}
+	}
+
+	logger := l.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	logger.Println(b.String())
+}
+
+func (noopLogger) Debug(msg string, args ...interface{}) {}
+func (noopLogger) Info(msg string, args ...interface{})  {}
+func (noopLogger) Warn(msg string, args ...interface{})  {}
+func (noopLogger) Error(msg string, args ...interface{}) {}
+
+// logger returns the configured logger or a logger discarding everything
+func (client *Client) logger() Logger {
+	if client.Logger == nil {
+		return noopLogger{}
+	}
+
+	return client.Logger
+}
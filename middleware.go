@@ -0,0 +1,83 @@
+package uipath
+
+import (
+	"net/http"
+	"time"
+)
+
+// Call describes an api call going through the middlewares
+type Call struct {
+	// Request is the prepared request, including the authorization header
+	Request *http.Request
+
+	// Body is a copy of the request body, changing it does not change the request
+	Body []byte
+
+	// Endpoint is the endpoint template with the keys replaced, eg. Assets({key})
+	Endpoint string
+	FolderID uint
+}
+
+// Invoker sends the call and returns the response with its body. The response is nil when it
+// could not be received and the error is an *APIError when the orchestrator answered with a failure.
+type Invoker func(call *Call) (*http.Response, []byte, error)
+
+// Middleware wraps the invoker of the next middleware
+type Middleware func(next Invoker) Invoker
+
+// Use appends middlewares to the chain of the client
+func (client *Client) Use(middlewares ...Middleware) {
+	client.Middlewares = append(client.Middlewares, middlewares...)
+}
+
+// chain wraps the invoker with the middlewares of the client
+func (client *Client) chain(invoker Invoker) Invoker {
+	for i := len(client.Middlewares) - 1; i >= 0; i-- {
+		invoker = client.Middlewares[i](invoker)
+	}
+
+	return invoker
+}
+
+// HeaderMiddleware sets the headers on every request
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next Invoker) Invoker {
+		return func(call *Call) (*http.Response, []byte, error) {
+			for k, v := range headers {
+				call.Request.Header.Set(k, v)
+			}
+
+			return next(call)
+		}
+	}
+}
+
+// LoggingMiddleware logs every api call with its endpoint, folder, status and duration
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Invoker) Invoker {
+		return func(call *Call) (*http.Response, []byte, error) {
+			start := time.Now()
+
+			resp, body, err := next(call)
+
+			args := []interface{}{
+				"method", call.Request.Method,
+				"endpoint", call.Endpoint,
+				"folder", call.FolderID,
+				"duration", time.Since(start),
+			}
+
+			if resp != nil {
+				args = append(args, "status", resp.StatusCode)
+			}
+
+			if err != nil {
+				logger.Warn("uipath call failed", append(args, "error", err.Error())...)
+			} else {
+				logger.Info("uipath call", args...)
+			}
+
+			return resp, body, err
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package uipath
+
+import (
+	"net/url"
+	"strings"
+)
+
+// listPageSize is the number of items fetched per page when following list pages
+const listPageSize = 100
+
+// odataLiteral quotes a string for use inside an odata filter or function call
+func odataLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// odataPathLiteral quotes and escapes a string for use inside a function call in the url path
+func odataPathLiteral(value string) string {
+	return url.PathEscape(odataLiteral(value))
+}
@@ -0,0 +1,152 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	QueueDefinitionEndpoint       = "QueueDefinitions"
+	QueueProcessingStatusEndpoint = "QueueProcessingRecords/UiPathODataSvc.RetrieveQueuesProcessingStatus"
+)
+
+// QueueHandler struct defines what the queue definition handler looks like
+type QueueHandler struct {
+	Client   *Client
+	FolderId uint
+}
+
+// QueueDefinition struct defines what the queue definition model looks like
+type QueueDefinition struct {
+	ID                                 uint   `json:"Id,omitempty"`
+	Key                                string `json:"Key,omitempty"`
+	Name                               string `json:"Name"`
+	Description                        string `json:"Description,omitempty"`
+	MaxNumberOfRetries                 int    `json:"MaxNumberOfRetries,omitempty"`
+	AcceptAutomaticallyRetry           bool   `json:"AcceptAutomaticallyRetry,omitempty"`
+	EnforceUniqueReference             bool   `json:"EnforceUniqueReference,omitempty"`
+	SpecificDataJsonSchema             string `json:"SpecificDataJsonSchema,omitempty"`
+	OutputDataJsonSchema               string `json:"OutputDataJsonSchema,omitempty"`
+	AnalyticsDataJsonSchema            string `json:"AnalyticsDataJsonSchema,omitempty"`
+	SlaInMinutes                       int    `json:"SlaInMinutes,omitempty"`
+	RiskSlaInMinutes                   int    `json:"RiskSlaInMinutes,omitempty"`
+	ReleaseID                          *uint  `json:"ReleaseId,omitempty"`
+	CreationTime                       string `json:"CreationTime,omitempty"`
+	OrganizationUnitID                 uint   `json:"OrganizationUnitId,omitempty"`
+	OrganizationUnitFullyQualifiedName string `json:"OrganizationUnitFullyQualifiedName,omitempty"`
+}
+
+// QueueDefinitionList defines what the queue definition list model looks like
+type QueueDefinitionList struct {
+	Count int               `json:"@odata.count"`
+	Value []QueueDefinition `json:"value"`
+}
+
+// QueueProcessingStatus defines the processing summary of a queue, the processing times are in seconds
+type QueueProcessingStatus struct {
+	QueueDefinitionID                    uint    `json:"QueueDefinitionId"`
+	QueueDefinitionKey                   string  `json:"QueueDefinitionKey,omitempty"`
+	QueueDefinitionName                  string  `json:"QueueDefinitionName"`
+	ItemsToProcess                       int     `json:"ItemsToProcess"`
+	ItemsInProgress                      int     `json:"ItemsInProgress"`
+	ProcessingMeanTime                   float64 `json:"ProcessingMeanTime"`
+	SuccessfulTransactionsNo             int     `json:"SuccessfulTransactionsNo"`
+	ApplicationExceptionsNo              int     `json:"ApplicationExceptionsNo"`
+	BusinessExceptionsNo                 int     `json:"BusinessExceptionsNo"`
+	SuccessfulTransactionsProcessingTime float64 `json:"SuccessfulTransactionsProcessingTime"`
+	ApplicationExceptionsProcessingTime  float64 `json:"ApplicationExceptionsProcessingTime"`
+	BusinessExceptionsProcessingTime     float64 `json:"BusinessExceptionsProcessingTime"`
+	TotalNumberOfTransactions            int     `json:"TotalNumberOfTransactions"`
+	LastProcessed                        string  `json:"LastProcessed,omitempty"`
+	ReleaseName                          string  `json:"ReleaseName,omitempty"`
+}
+
+// QueueProcessingStatusList defines what the processing status list model looks like
+type QueueProcessingStatusList struct {
+	Count int                     `json:"@odata.count"`
+	Value []QueueProcessingStatus `json:"value"`
+}
+
+// GetByID fetches a queue definition by id
+func (q *QueueHandler) GetByID(ID uint) (QueueDefinition, error) {
+	var queue QueueDefinition
+
+	url := fmt.Sprintf("%s%s(%d)", q.Client.BaseURL, QueueDefinitionEndpoint, ID)
+
+	resp, err := q.Client.SendWithAuthorization("GET", url, nil, q.buildHeaders(), map[string]string{})
+	if err != nil {
+		return queue, err
+	}
+
+	err = json.Unmarshal(resp, &queue)
+
+	return queue, err
+}
+
+// GetByName fetches a queue definition by name, the queue definition is empty when not found
+func (q *QueueHandler) GetByName(name string) (QueueDefinition, error) {
+	var queueList QueueDefinitionList
+	var queue QueueDefinition
+
+	params := url.Values{}
+	params.Set("$filter", fmt.Sprintf("Name eq %s", odataLiteral(name)))
+
+	url := fmt.Sprintf("%s%s?%s", q.Client.BaseURL, QueueDefinitionEndpoint, params.Encode())
+
+	resp, err := q.Client.SendWithAuthorization("GET", url, nil, q.buildHeaders(), map[string]string{})
+	if err != nil {
+		return queue, err
+	}
+
+	if err = json.Unmarshal(resp, &queueList); err != nil {
+		return queue, err
+	}
+
+	if len(queueList.Value) < 1 {
+		return queue, nil
+	}
+
+	return queueList.Value[0], nil
+}
+
+// List fetches a list of queue definitions that can be filtered using query parameters
+func (q *QueueHandler) List(filters map[string]string) ([]QueueDefinition, int, error) {
+	var queueList QueueDefinitionList
+
+	url := fmt.Sprintf("%s%s", q.Client.BaseURL, QueueDefinitionEndpoint)
+
+	resp, err := q.Client.SendWithAuthorization("GET", url, nil, q.buildHeaders(), filters)
+	if err != nil {
+		return queueList.Value, queueList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &queueList)
+
+	return queueList.Value, queueList.Count, err
+}
+
+// ProcessingStatus fetches the processing summary of the queues of the folder
+func (q *QueueHandler) ProcessingStatus(filters map[string]string) ([]QueueProcessingStatus, error) {
+	var statusList QueueProcessingStatusList
+
+	url := fmt.Sprintf("%s%s", q.Client.BaseURL, QueueProcessingStatusEndpoint)
+
+	resp, err := q.Client.SendWithAuthorization("GET", url, nil, q.buildHeaders(), filters)
+	if err != nil {
+		return statusList.Value, err
+	}
+
+	err = json.Unmarshal(resp, &statusList)
+
+	return statusList.Value, err
+}
+
+func (q *QueueHandler) buildHeaders() map[string]string {
+	var headers = map[string]string{}
+
+	headers[HeaderOrganizationUnitId] = strconv.Itoa(int(q.FolderId))
+
+	return headers
+}
@@ -0,0 +1,287 @@
+package uipath
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+
+	SpecificContentColumnPrefix = "SpecificContent."
+	OutputColumnPrefix          = "Output."
+)
+
+// queueItemExportColumns are the fixed columns of an exported queue item, before its content columns
+var queueItemExportColumns = []string{
+	"Id",
+	"QueueDefinitionId",
+	"Key",
+	"Reference",
+	"Status",
+	"ReviewStatus",
+	"Priority",
+	"CreationTime",
+	"StartProcessing",
+	"EndProcessing",
+	"DeferDate",
+	"DueDate",
+	"RiskSlaDate",
+	"RetryNumber",
+	"SecondsInPreviousAttempts",
+	"ProcessingExceptionType",
+	"ProcessingException.Reason",
+	"ProcessingException.Details",
+	"ProcessingException.Type",
+}
+
+// QueueItemFilter selects the queue items to export
+type QueueItemFilter struct {
+	QueueDefinitionID uint
+
+	// Statuses keeps the items with one of the statuses, eg. QueueItemStatusSuccessful
+	Statuses []string
+
+	// From and To bound the TimeField, From is inclusive and To exclusive, zero values are not bounds
+	From time.Time
+	To   time.Time
+
+	// TimeField is the date field bounded by From and To, it defaults to CreationTime
+	TimeField string
+}
+
+// QueueExporter writes queue items as flattened rows
+type QueueExporter struct {
+	Handler *QueueItemHandler
+	Filter  QueueItemFilter
+
+	// Format is ExportFormatCSV or ExportFormatNDJSON, it defaults to csv
+	Format string
+
+	// Columns fixes the csv columns, by default the csv export reads every item first to find all the content columns
+	Columns []string
+}
+
+// OData returns the odata $filter of the filter, empty when it selects everything
+func (f QueueItemFilter) OData() string {
+	var clauses []string
+
+	if f.QueueDefinitionID != 0 {
+		clauses = append(clauses, fmt.Sprintf("QueueDefinitionId eq %d", f.QueueDefinitionID))
+	}
+
+	if len(f.Statuses) > 0 {
+		var statuses []string
+		for _, status := range f.Statuses {
+			statuses = append(statuses, fmt.Sprintf("Status eq %s", odataLiteral(status)))
+		}
+
+		clauses = append(clauses, "("+strings.Join(statuses, " or ")+")")
+	}
+
+	timeField := f.TimeField
+	if timeField == "" {
+		timeField = "CreationTime"
+	}
+
+	if !f.From.IsZero() {
+		clauses = append(clauses, fmt.Sprintf("%s ge %s", timeField, f.From.UTC().Format(time.RFC3339)))
+	}
+
+	if !f.To.IsZero() {
+		clauses = append(clauses, fmt.Sprintf("%s lt %s", timeField, f.To.UTC().Format(time.RFC3339)))
+	}
+
+	return strings.Join(clauses, " and ")
+}
+
+// Each calls fn with every queue item matching the filter
+func (e *QueueExporter) Each(fn func(item QueueItem) error) error {
+	return e.Handler.Each(e.Filter.OData(), fn)
+}
+
+// Export writes the queue items to w and returns how many were written
+func (e *QueueExporter) Export(w io.Writer) (int, error) {
+	switch e.Format {
+	case "", ExportFormatCSV:
+		return e.exportCSV(w)
+	case ExportFormatNDJSON:
+		return e.exportNDJSON(w)
+	}
+
+	return 0, fmt.Errorf("unsupported export format: %s", e.Format)
+}
+
+func (e *QueueExporter) exportNDJSON(w io.Writer) (int, error) {
+	count := 0
+	encoder := json.NewEncoder(w)
+
+	err := e.Each(func(item QueueItem) error {
+		count++
+		return encoder.Encode(FlattenQueueItem(item))
+	})
+
+	return count, err
+}
+
+func (e *QueueExporter) exportCSV(w io.Writer) (int, error) {
+	writer := csv.NewWriter(w)
+	count := 0
+
+	write := func(columns []string, row map[string]interface{}) error {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = exportString(row[column])
+		}
+
+		count++
+
+		return writer.Write(record)
+	}
+
+	if len(e.Columns) > 0 {
+		if err := writer.Write(e.Columns); err != nil {
+			return count, err
+		}
+
+		err := e.Each(func(item QueueItem) error {
+			return write(e.Columns, FlattenQueueItem(item))
+		})
+
+		writer.Flush()
+		if err == nil {
+			err = writer.Error()
+		}
+
+		return count, err
+	}
+
+	var rows []map[string]interface{}
+	contentColumns := map[string]bool{}
+
+	err := e.Each(func(item QueueItem) error {
+		row := FlattenQueueItem(item)
+		for column := range row {
+			contentColumns[column] = true
+		}
+
+		rows = append(rows, row)
+
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	columns := QueueItemExportColumns(contentColumns)
+	if err := writer.Write(columns); err != nil {
+		return count, err
+	}
+
+	for _, row := range rows {
+		if err := write(columns, row); err != nil {
+			return count, err
+		}
+	}
+
+	writer.Flush()
+
+	return count, writer.Error()
+}
+
+// QueueItemExportColumns returns the fixed columns followed by the sorted specific content and output columns
+func QueueItemExportColumns(columns map[string]bool) []string {
+	result := append([]string{}, queueItemExportColumns...)
+
+	var specificContent, output []string
+	for column := range columns {
+		switch {
+		case strings.HasPrefix(column, SpecificContentColumnPrefix):
+			specificContent = append(specificContent, column)
+		case strings.HasPrefix(column, OutputColumnPrefix):
+			output = append(output, column)
+		}
+	}
+
+	sort.Strings(specificContent)
+	sort.Strings(output)
+
+	result = append(result, specificContent...)
+
+	return append(result, output...)
+}
+
+// FlattenQueueItem flattens a queue item into a single level row, nested SpecificContent and Output
+// values get dotted column names like SpecificContent.Invoice.Number and lists are kept as json
+func FlattenQueueItem(item QueueItem) map[string]interface{} {
+	row := map[string]interface{}{
+		"Id":                        item.ID,
+		"QueueDefinitionId":         item.QueueDefinitionID,
+		"Key":                       item.Key,
+		"Reference":                 item.Reference,
+		"Status":                    item.Status,
+		"ReviewStatus":              item.ReviewStatus,
+		"Priority":                  item.Priority,
+		"CreationTime":              item.CreationTime,
+		"StartProcessing":           item.StartProcessing,
+		"EndProcessing":             item.EndProcessing,
+		"DeferDate":                 item.DeferDate,
+		"DueDate":                   item.DueDate,
+		"RiskSlaDate":               item.RiskSlaDate,
+		"RetryNumber":               item.RetryNumber,
+		"SecondsInPreviousAttempts": item.SecondsInPreviousAttempts,
+		"ProcessingExceptionType":   item.ProcessingExceptionType,
+	}
+
+	if item.ProcessingException != nil {
+		row["ProcessingException.Reason"] = item.ProcessingException.Reason
+		row["ProcessingException.Details"] = item.ProcessingException.Details
+		row["ProcessingException.Type"] = item.ProcessingException.Type
+	}
+
+	flattenInto(row, strings.TrimSuffix(SpecificContentColumnPrefix, "."), item.SpecificContent)
+	flattenInto(row, strings.TrimSuffix(OutputColumnPrefix, "."), item.Output)
+
+	return row
+}
+
+func flattenInto(row map[string]interface{}, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		column := prefix + "." + key
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenInto(row, column, v)
+		case []interface{}:
+			data, err := json.Marshal(v)
+			if err != nil {
+				row[column] = fmt.Sprint(v)
+				continue
+			}
+
+			row[column] = string(data)
+		default:
+			row[column] = v
+		}
+	}
+}
+
+func exportString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,74 @@
+package uipath
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *QueueItemTestSuite) TestQueueItemFilterOData() {
+	filter := QueueItemFilter{
+		QueueDefinitionID: 3,
+		Statuses:          []string{QueueItemStatusSuccessful, QueueItemStatusFailed},
+		From:              time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:                time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		TimeField:         "EndProcessing",
+	}
+
+	assert.Equal(suite.T(), "QueueDefinitionId eq 3 and (Status eq 'Successful' or Status eq 'Failed') and EndProcessing ge 2024-05-01T00:00:00Z and EndProcessing lt 2024-06-01T00:00:00Z", filter.OData())
+	assert.Equal(suite.T(), "", QueueItemFilter{}.OData())
+}
+
+func (suite *QueueItemTestSuite) TestExportCSVFlattensContentAcrossPages() {
+	var filters []string
+
+	httpmock.RegisterResponder("GET", testBaseURL+QueueItemEndpoint, func(req *http.Request) (*http.Response, error) {
+		filter := req.URL.Query().Get("$filter")
+		filters = append(filters, filter)
+
+		// A full first page forces a second request after the last id
+		var page QueueItemList
+		if !strings.Contains(filter, "Id gt") {
+			for i := 1; i <= listPageSize; i++ {
+				page.Value = append(page.Value, QueueItem{ID: uint(i), Status: QueueItemStatusSuccessful, SpecificContent: map[string]interface{}{"Invoice": map[string]interface{}{"Number": float64(i)}}})
+			}
+
+			return httpmock.NewJsonResponse(200, page)
+		}
+
+		page.Value = []QueueItem{{
+			ID:                  1000,
+			Status:              QueueItemStatusFailed,
+			Reference:           "INV-1000",
+			SpecificContent:     map[string]interface{}{"Lines": []interface{}{"a", "b"}},
+			Output:              map[string]interface{}{"ErpId": "X9"},
+			ProcessingException: &ProcessingException{Reason: "Invalid vendor", Type: "BusinessException"},
+		}}
+
+		return httpmock.NewJsonResponse(200, page)
+	})
+
+	var out bytes.Buffer
+
+	exporter := QueueExporter{
+		Handler: suite.h,
+		Filter:  QueueItemFilter{Statuses: []string{QueueItemStatusSuccessful, QueueItemStatusFailed}},
+	}
+
+	count, err := exporter.Export(&out)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), listPageSize+1, count)
+	assert.Equal(suite.T(), "((Status eq 'Successful' or Status eq 'Failed')) and Id gt 100", filters[1])
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(suite.T(), lines, listPageSize+2)
+	assert.True(suite.T(), strings.HasSuffix(lines[0], ",SpecificContent.Invoice.Number,SpecificContent.Lines,Output.ErpId"))
+	assert.True(suite.T(), strings.HasSuffix(lines[1], ",1,,"))
+	assert.Contains(suite.T(), lines[len(lines)-1], `1000,0,,INV-1000,Failed`)
+	assert.Contains(suite.T(), lines[len(lines)-1], `Invalid vendor,,BusinessException,,"[""a"",""b""]",X9`)
+}
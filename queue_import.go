@@ -0,0 +1,670 @@
+package uipath
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultImportBatchSize   = 100
+	defaultImportMaxAttempts = 3
+	maxReferenceLength       = 128
+)
+
+// ErrProgressMismatch is returned when resuming an import with the progress file of another source
+var ErrProgressMismatch = errors.New("uipath: progress file belongs to another import")
+
+// queueImportDateLayouts are the date formats accepted for the due and defer dates
+var queueImportDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// QueueImportMapping maps the columns of the source records to the queue item fields
+type QueueImportMapping struct {
+	Reference string `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Priority  string `json:"priority,omitempty" yaml:"priority,omitempty"`
+	DueDate   string `json:"dueDate,omitempty" yaml:"dueDate,omitempty"`
+	DeferDate string `json:"deferDate,omitempty" yaml:"deferDate,omitempty"`
+
+	// SpecificContent maps the specific content keys to their column
+	SpecificContent map[string]string `json:"specificContent,omitempty" yaml:"specificContent,omitempty"`
+
+	// IncludeUnmapped adds the columns mapped to nothing to the specific content under their own name
+	IncludeUnmapped bool `json:"includeUnmapped,omitempty" yaml:"includeUnmapped,omitempty"`
+
+	// Required lists the columns that must have a value
+	Required []string `json:"required,omitempty" yaml:"required,omitempty"`
+
+	// DefaultPriority is used when the priority column is empty, it defaults to Normal
+	DefaultPriority string `json:"defaultPriority,omitempty" yaml:"defaultPriority,omitempty"`
+}
+
+// QueueImportRecord is a row of the source, with its line to report and resume from
+type QueueImportRecord struct {
+	Line   int
+	Values map[string]interface{}
+}
+
+// QueueImportReader reads the source records one by one, it returns io.EOF at the end. A record
+// that cannot be read is returned with a *QueueImportRecordError, the import fails it and carries on.
+type QueueImportReader interface {
+	Read() (QueueImportRecord, error)
+}
+
+// QueueImportRecordError is a record of the source that cannot be read, eg. a malformed json line
+type QueueImportRecordError struct {
+	Line int
+	Err  error
+}
+
+// QueueImportFailure is a record that could not be imported, written as a json line to the failures file
+type QueueImportFailure struct {
+	Line      int                    `json:"line"`
+	Reference string                 `json:"reference,omitempty"`
+	Error     string                 `json:"error"`
+	Record    map[string]interface{} `json:"record,omitempty"`
+}
+
+// QueueImportProgress is saved after every batch so an interrupted import can be resumed
+type QueueImportProgress struct {
+	Source    string    `json:"source"`
+	Line      int       `json:"line"`
+	Imported  int       `json:"imported"`
+	Failed    int       `json:"failed"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// QueueImportReport sums up an import run
+type QueueImportReport struct {
+	Imported int
+	Failed   int
+
+	// Skipped counts the records imported by a previous run
+	Skipped  int
+	Failures []QueueImportFailure
+}
+
+// QueueImporter pushes records to a queue in batches
+type QueueImporter struct {
+	Handler *QueueItemHandler
+	Queue   string
+
+	// Mapping maps the records to queue items, see DefaultQueueImportMapping
+	Mapping QueueImportMapping
+
+	// BatchSize is the number of items sent per request, it defaults to 100
+	BatchSize int
+
+	// CommitType is the bulk commit type, it defaults to ProcessAllIndependently
+	CommitType string
+
+	// MaxAttempts is the number of times a batch failing with a retryable error is sent, it defaults to 3
+	MaxAttempts int
+
+	// ProgressFile saves the progress after every batch, the import resumes from it when it exists
+	ProgressFile string
+
+	// FailuresFile receives the failed records as json lines
+	FailuresFile string
+
+	// OnProgress is called after every batch
+	OnProgress func(progress QueueImportProgress)
+}
+
+// queueImportEntry is a record of the batch being built, either an item to send or a validation failure
+type queueImportEntry struct {
+	record  QueueImportRecord
+	item    QueueItem
+	failure *QueueImportFailure
+}
+
+// DefaultQueueImportMapping maps the Reference, Priority, DueDate and DeferDate columns to the
+// queue item fields and the other columns to the specific content
+func DefaultQueueImportMapping() QueueImportMapping {
+	return QueueImportMapping{
+		Reference:       "Reference",
+		Priority:        "Priority",
+		DueDate:         "DueDate",
+		DeferDate:       "DeferDate",
+		IncludeUnmapped: true,
+	}
+}
+
+// LoadQueueImportMapping reads a yaml or json mapping file
+func LoadQueueImportMapping(path string) (QueueImportMapping, error) {
+	var mapping QueueImportMapping
+
+	err := unmarshalConfigFile(path, &mapping)
+
+	return mapping, err
+}
+
+// QueueItem builds and validates the queue item of a record
+func (m QueueImportMapping) QueueItem(queue string, record QueueImportRecord) (QueueItem, error) {
+	item := QueueItem{
+		Name:            queue,
+		SpecificContent: map[string]interface{}{},
+	}
+
+	for _, column := range m.Required {
+		if recordString(record, column) == "" {
+			return item, fmt.Errorf("missing required column %s", column)
+		}
+	}
+
+	item.Reference = recordString(record, m.Reference)
+	if len(item.Reference) > maxReferenceLength {
+		return item, fmt.Errorf("reference is longer than %d characters", maxReferenceLength)
+	}
+
+	priority, err := normalizePriority(recordString(record, m.Priority), m.DefaultPriority)
+	if err != nil {
+		return item, err
+	}
+
+	item.Priority = priority
+
+	dueDate, err := parseImportDate(recordString(record, m.DueDate))
+	if err != nil {
+		return item, fmt.Errorf("invalid due date: %w", err)
+	}
+
+	deferDate, err := parseImportDate(recordString(record, m.DeferDate))
+	if err != nil {
+		return item, fmt.Errorf("invalid defer date: %w", err)
+	}
+
+	if !dueDate.IsZero() && !deferDate.IsZero() && deferDate.After(dueDate) {
+		return item, errors.New("defer date is after the due date")
+	}
+
+	if !dueDate.IsZero() {
+		item.DueDate = dueDate.Format(time.RFC3339)
+	}
+
+	if !deferDate.IsZero() {
+		item.DeferDate = deferDate.Format(time.RFC3339)
+	}
+
+	mapped := map[string]bool{m.Reference: true, m.Priority: true, m.DueDate: true, m.DeferDate: true}
+
+	for key, column := range m.SpecificContent {
+		mapped[column] = true
+
+		if value, ok := record.Values[column]; ok {
+			item.SpecificContent[key] = value
+		}
+	}
+
+	if m.IncludeUnmapped {
+		for column, value := range record.Values {
+			if !mapped[column] {
+				item.SpecificContent[column] = value
+			}
+		}
+	}
+
+	return item, nil
+}
+
+func (e *QueueImportRecordError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// Unwrap returns the error reading the record
+func (e *QueueImportRecordError) Unwrap() error {
+	return e.Err
+}
+
+// NewCSVQueueImportReader reads the records of a csv source, the header row names the columns.
+// Rows with another number of columns than the header are failed instead of ending the import.
+func NewCSVQueueImportReader(r io.Reader) QueueImportReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	return &csvQueueImportReader{reader: reader}
+}
+
+type csvQueueImportReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (c *csvQueueImportReader) Read() (QueueImportRecord, error) {
+	var record QueueImportRecord
+
+	if c.header == nil {
+		header, err := c.reader.Read()
+		if err != nil {
+			return record, err
+		}
+
+		// Spreadsheet exports start with a byte order mark which would end up in the first column name
+		if len(header) > 0 {
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		}
+
+		c.header = header
+	}
+
+	row, err := c.reader.Read()
+
+	var parseError *csv.ParseError
+	if errors.As(err, &parseError) {
+		record.Line = parseError.StartLine
+		return record, &QueueImportRecordError{Line: record.Line, Err: parseError.Err}
+	}
+
+	if err != nil {
+		return record, err
+	}
+
+	record.Line, _ = c.reader.FieldPos(0)
+
+	if len(row) != len(c.header) {
+		return record, &QueueImportRecordError{Line: record.Line, Err: fmt.Errorf("row has %d columns, the header has %d", len(row), len(c.header))}
+	}
+
+	record.Values = map[string]interface{}{}
+
+	for i, column := range c.header {
+		record.Values[column] = row[i]
+	}
+
+	return record, nil
+}
+
+// NewNDJSONQueueImportReader reads the records of a json lines source, one object per line
+func NewNDJSONQueueImportReader(r io.Reader) QueueImportReader {
+	return &ndjsonQueueImportReader{reader: bufio.NewReader(r)}
+}
+
+type ndjsonQueueImportReader struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func (n *ndjsonQueueImportReader) Read() (QueueImportRecord, error) {
+	var record QueueImportRecord
+
+	for {
+		data, err := n.reader.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(data) == 0) {
+			return record, err
+		}
+
+		n.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		record.Line = n.line
+
+		if err := decoder.Decode(&record.Values); err != nil {
+			return record, &QueueImportRecordError{Line: n.line, Err: err}
+		}
+
+		return record, nil
+	}
+}
+
+// ImportFile imports a .csv, .ndjson or .jsonl file
+func (i *QueueImporter) ImportFile(path string) (QueueImportReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return QueueImportReport{}, err
+	}
+	defer f.Close()
+
+	var reader QueueImportReader
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		reader = NewCSVQueueImportReader(f)
+	case ".ndjson", ".jsonl":
+		reader = NewNDJSONQueueImportReader(f)
+	default:
+		return QueueImportReport{}, fmt.Errorf("unsupported import format: %s", path)
+	}
+
+	return i.Import(path, reader)
+}
+
+// Import reads the records, validates them and sends them in batches, the source names the
+// records in the progress file
+func (i *QueueImporter) Import(source string, reader QueueImportReader) (QueueImportReport, error) {
+	var report QueueImportReport
+
+	progress, err := i.loadProgress(source)
+	if err != nil {
+		return report, err
+	}
+
+	failures, err := i.openFailures()
+	if err != nil {
+		return report, err
+	}
+
+	if failures != nil {
+		defer failures.Close()
+	}
+
+	batchSize := i.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	var batch []queueImportEntry
+	items := 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		batchFailures := i.sendBatch(batch)
+
+		for _, failure := range batchFailures {
+			if failures != nil {
+				if err := json.NewEncoder(failures).Encode(failure); err != nil {
+					return err
+				}
+			}
+		}
+
+		invalid := len(batch) - items
+		imported := items - (len(batchFailures) - invalid)
+
+		report.Failures = append(report.Failures, batchFailures...)
+		report.Failed += len(batchFailures)
+		report.Imported += imported
+
+		progress.Line = batch[len(batch)-1].record.Line
+		progress.Failed += len(batchFailures)
+		progress.Imported += imported
+		progress.UpdatedAt = time.Now()
+
+		if err := i.saveProgress(progress); err != nil {
+			return err
+		}
+
+		if i.OnProgress != nil {
+			i.OnProgress(progress)
+		}
+
+		batch = batch[:0]
+		items = 0
+
+		return i.Handler.Client.Context().Err()
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var recordError *QueueImportRecordError
+		if err != nil && !errors.As(err, &recordError) {
+			return report, err
+		}
+
+		if record.Line <= progress.Line {
+			report.Skipped++
+			continue
+		}
+
+		entry := queueImportEntry{record: record}
+
+		if recordError != nil {
+			entry.failure = newQueueImportFailure(record, "", recordError.Err.Error())
+		} else if entry.item, err = i.Mapping.QueueItem(i.Queue, record); err != nil {
+			entry.failure = newQueueImportFailure(record, entry.item.Reference, err.Error())
+		} else {
+			items++
+		}
+
+		batch = append(batch, entry)
+
+		if items >= batchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	return report, flush()
+}
+
+// sendBatch sends the valid items of the batch and returns the failures of the whole batch
+func (i *QueueImporter) sendBatch(batch []queueImportEntry) []QueueImportFailure {
+	var failures []QueueImportFailure
+	var sent []queueImportEntry
+	var queueItems []QueueItem
+
+	for _, entry := range batch {
+		if entry.failure != nil {
+			failures = append(failures, *entry.failure)
+			continue
+		}
+
+		sent = append(sent, entry)
+		queueItems = append(queueItems, entry.item)
+	}
+
+	if len(queueItems) == 0 {
+		return failures
+	}
+
+	bulkFailures, err := i.bulkStore(queueItems)
+	if err != nil {
+		for _, entry := range sent {
+			failures = append(failures, *newQueueImportFailure(entry.record, entry.item.Reference, err.Error()))
+		}
+
+		return failures
+	}
+
+	return append(failures, matchBulkFailures(sent, bulkFailures)...)
+}
+
+// bulkStore sends the items again while the request fails with a retryable error
+func (i *QueueImporter) bulkStore(queueItems []QueueItem) ([]QueueItemBulkFailure, error) {
+	maxAttempts := i.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultImportMaxAttempts
+	}
+
+	client := i.Handler.Client
+	info := RequestInfo{Method: "POST", Endpoint: QueueBulkAddItemsEndpoint, FolderID: i.Handler.FolderId}
+
+	for attempt := 1; ; attempt++ {
+		bulkFailures, err := i.Handler.BulkStore(i.Queue, queueItems, i.CommitType)
+		if err == nil || attempt >= maxAttempts || !IsRetryable(err) {
+			return bulkFailures, err
+		}
+
+		client.instrumentation().RecordRetry(info, attempt, err)
+
+		if err := sleepContext(client.Context(), time.Duration(attempt)*time.Second); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// matchBulkFailures finds the records of the items the orchestrator rejected, by reference
+// or else by specific content
+func matchBulkFailures(sent []queueImportEntry, bulkFailures []QueueItemBulkFailure) []QueueImportFailure {
+	var failures []QueueImportFailure
+
+	matched := make([]bool, len(sent))
+
+	for _, bulkFailure := range bulkFailures {
+		index := -1
+
+		for j, entry := range sent {
+			if matched[j] {
+				continue
+			}
+
+			if sameBulkItem(entry.item, bulkFailure.ItemData) {
+				index = j
+				break
+			}
+		}
+
+		if index < 0 {
+			failures = append(failures, QueueImportFailure{
+				Reference: bulkFailure.ItemData.Reference,
+				Error:     bulkFailure.ErrorMessage,
+				Record:    bulkFailure.ItemData.SpecificContent,
+			})
+
+			continue
+		}
+
+		matched[index] = true
+		failures = append(failures, *newQueueImportFailure(sent[index].record, sent[index].item.Reference, bulkFailure.ErrorMessage))
+	}
+
+	return failures
+}
+
+func sameBulkItem(item QueueItem, failed QueueItem) bool {
+	if item.Reference != "" || failed.Reference != "" {
+		return item.Reference == failed.Reference
+	}
+
+	a, errA := json.Marshal(item.SpecificContent)
+	b, errB := json.Marshal(failed.SpecificContent)
+
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+func (i *QueueImporter) loadProgress(source string) (QueueImportProgress, error) {
+	progress := QueueImportProgress{Source: source}
+
+	if i.ProgressFile == "" {
+		return progress, nil
+	}
+
+	data, err := os.ReadFile(i.ProgressFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+
+	if err != nil {
+		return progress, err
+	}
+
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return progress, err
+	}
+
+	if progress.Source != source {
+		return progress, fmt.Errorf("%w: %s", ErrProgressMismatch, progress.Source)
+	}
+
+	return progress, nil
+}
+
+// saveProgress replaces the progress file atomically so a crash never leaves it half written
+func (i *QueueImporter) saveProgress(progress QueueImportProgress) error {
+	if i.ProgressFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := i.ProgressFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, i.ProgressFile)
+}
+
+func (i *QueueImporter) openFailures() (*os.File, error) {
+	if i.FailuresFile == "" {
+		return nil, nil
+	}
+
+	return os.OpenFile(i.FailuresFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+}
+
+func newQueueImportFailure(record QueueImportRecord, reference string, message string) *QueueImportFailure {
+	return &QueueImportFailure{
+		Line:      record.Line,
+		Reference: reference,
+		Error:     message,
+		Record:    record.Values,
+	}
+}
+
+// recordString returns the value of the column as a string, empty when the column is not set
+func recordString(record QueueImportRecord, column string) string {
+	if column == "" {
+		return ""
+	}
+
+	switch value := record.Values[column].(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func normalizePriority(priority string, defaultPriority string) (string, error) {
+	if priority == "" {
+		priority = defaultPriority
+	}
+
+	if priority == "" {
+		return PriorityNormal, nil
+	}
+
+	for _, p := range []string{PriorityLow, PriorityNormal, PriorityHigh} {
+		if strings.EqualFold(priority, p) {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid priority %q, use %s, %s or %s", priority, PriorityLow, PriorityNormal, PriorityHigh)
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range queueImportDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date %q", value)
+}
@@ -0,0 +1,174 @@
+package uipath
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testImportCSV = `Reference,Priority,DueDate,InvoiceNumber,Amount
+INV-1,High,2024-05-01,1001,10.5
+INV-2,Urgent,,1002,20
+INV-3,low,2024-05-03T10:00:00Z,1003,30
+INV-4,,,1004,40
+`
+
+// registerBulkResponder records the sent batches and rejects the items with the given references
+func (suite *QueueItemTestSuite) registerBulkResponder(batches *[]QueueItemBulkCreateRequest, rejected ...string) {
+	httpmock.RegisterResponder("POST", testBaseURL+QueueBulkAddItemsEndpoint, func(req *http.Request) (*http.Response, error) {
+		var request QueueItemBulkCreateRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			return nil, err
+		}
+
+		*batches = append(*batches, request)
+
+		var failures QueueItemBulkFailureList
+		for _, item := range request.QueueItems {
+			for _, reference := range rejected {
+				if item.Reference == reference {
+					failures.Value = append(failures.Value, QueueItemBulkFailure{ItemData: item, ErrorMessage: "Duplicate reference"})
+				}
+			}
+		}
+
+		return httpmock.NewJsonResponse(200, failures)
+	})
+}
+
+func (suite *QueueItemTestSuite) TestImportMapsValidatesAndBatches() {
+	var batches []QueueItemBulkCreateRequest
+	suite.registerBulkResponder(&batches, "INV-4")
+
+	failuresFile := filepath.Join(suite.T().TempDir(), "failures.ndjson")
+
+	importer := QueueImporter{
+		Handler:      suite.h,
+		Queue:        "Invoices",
+		Mapping:      DefaultQueueImportMapping(),
+		BatchSize:    2,
+		FailuresFile: failuresFile,
+	}
+
+	report, err := importer.Import("invoices.csv", NewCSVQueueImportReader(strings.NewReader(testImportCSV)))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, report.Imported)
+	assert.Equal(suite.T(), 2, report.Failed)
+
+	assert.Len(suite.T(), batches, 2)
+	assert.Equal(suite.T(), BulkCommitProcessAllIndependently, batches[0].CommitType)
+	assert.Equal(suite.T(), "Invoices", batches[0].QueueName)
+
+	first := batches[0].QueueItems[0]
+	assert.Equal(suite.T(), "INV-1", first.Reference)
+	assert.Equal(suite.T(), PriorityHigh, first.Priority)
+	assert.Equal(suite.T(), "2024-05-01T00:00:00Z", first.DueDate)
+	assert.Equal(suite.T(), map[string]interface{}{"InvoiceNumber": "1001", "Amount": "10.5"}, first.SpecificContent)
+	assert.Equal(suite.T(), PriorityLow, batches[0].QueueItems[1].Priority)
+
+	assert.Equal(suite.T(), 3, report.Failures[0].Line)
+	assert.Contains(suite.T(), report.Failures[0].Error, `invalid priority "Urgent"`)
+	assert.Equal(suite.T(), 5, report.Failures[1].Line)
+	assert.Equal(suite.T(), "Duplicate reference", report.Failures[1].Error)
+
+	data, err := os.ReadFile(failuresFile)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, strings.Count(string(data), "\n"))
+}
+
+func (suite *QueueItemTestSuite) TestImportResumesFromProgress() {
+	var batches []QueueItemBulkCreateRequest
+	suite.registerBulkResponder(&batches)
+
+	dir := suite.T().TempDir()
+	source := filepath.Join(dir, "invoices.ndjson")
+	progressFile := filepath.Join(dir, "progress.json")
+
+	ndjson := `{"Reference": "A", "Amount": 1}
+
+{"Reference": "B", "Amount": 2}
+{"Reference": "C", "Amount": 3}
+`
+	assert.Nil(suite.T(), os.WriteFile(source, []byte(ndjson), 0600))
+
+	progress, _ := json.Marshal(QueueImportProgress{Source: source, Line: 3, Imported: 2})
+	assert.Nil(suite.T(), os.WriteFile(progressFile, progress, 0600))
+
+	importer := QueueImporter{
+		Handler:      suite.h,
+		Queue:        "Invoices",
+		Mapping:      DefaultQueueImportMapping(),
+		ProgressFile: progressFile,
+	}
+
+	report, err := importer.ImportFile(source)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, report.Skipped)
+	assert.Equal(suite.T(), 1, report.Imported)
+	assert.Len(suite.T(), batches, 1)
+	assert.Equal(suite.T(), "C", batches[0].QueueItems[0].Reference)
+	assert.Equal(suite.T(), map[string]interface{}{"Amount": float64(3)}, batches[0].QueueItems[0].SpecificContent)
+
+	saved, err := importer.loadProgress(source)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 4, saved.Line)
+	assert.Equal(suite.T(), 3, saved.Imported)
+
+	_, err = importer.loadProgress("other.ndjson")
+	assert.ErrorIs(suite.T(), err, ErrProgressMismatch)
+}
+
+func (suite *QueueItemTestSuite) TestImportFailsMalformedRecordsAndCarriesOn() {
+	var batches []QueueItemBulkCreateRequest
+	suite.registerBulkResponder(&batches)
+
+	importer := QueueImporter{Handler: suite.h, Queue: "Invoices", Mapping: DefaultQueueImportMapping()}
+
+	ndjson := `{"Reference": "A"}
+{"Reference": "B",
+{"Reference": "C"}
+`
+
+	report, err := importer.Import("invoices.ndjson", NewNDJSONQueueImportReader(strings.NewReader(ndjson)))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, report.Imported)
+	assert.Equal(suite.T(), 1, report.Failed)
+	assert.Equal(suite.T(), 2, report.Failures[0].Line)
+	assert.Len(suite.T(), batches, 1)
+	assert.Len(suite.T(), batches[0].QueueItems, 2)
+
+	batches = nil
+
+	csv := "Reference,Amount\nD,1\nE\nF,3,extra\nG,\"4\"x\nH,5\n"
+
+	report, err = importer.Import("invoices.csv", NewCSVQueueImportReader(strings.NewReader(csv)))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, report.Imported)
+	assert.Equal(suite.T(), 3, report.Failed)
+	assert.Equal(suite.T(), []int{3, 4, 5}, []int{report.Failures[0].Line, report.Failures[1].Line, report.Failures[2].Line})
+	assert.Contains(suite.T(), report.Failures[0].Error, "row has 1 columns, the header has 2")
+	assert.Equal(suite.T(), "H", batches[0].QueueItems[1].Reference)
+}
+
+func (suite *QueueItemTestSuite) TestImportCSVWithByteOrderMark() {
+	var batches []QueueItemBulkCreateRequest
+	suite.registerBulkResponder(&batches)
+
+	importer := QueueImporter{Handler: suite.h, Queue: "Invoices", Mapping: DefaultQueueImportMapping()}
+
+	report, err := importer.Import("invoices.csv", NewCSVQueueImportReader(strings.NewReader("\ufeffReference,Amount\nINV-1,10\n")))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Imported)
+	assert.Equal(suite.T(), "INV-1", batches[0].QueueItems[0].Reference)
+	assert.Equal(suite.T(), map[string]interface{}{"Amount": "10"}, batches[0].QueueItems[0].SpecificContent)
+}
@@ -2,20 +2,42 @@ package uipath
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
 	"time"
 )
 
 const (
+	QueueItemStatusNew        = "New"
+	QueueItemStatusInProgress = "InProgress"
+	QueueItemStatusFailed     = "Failed"
+	QueueItemStatusSuccessful = "Successful"
+	QueueItemStatusAbandoned  = "Abandoned"
+	QueueItemStatusRetried    = "Retried"
+	QueueItemStatusDeleted    = "Deleted"
+
 	PriorityLow    = "Low"
 	PriorityNormal = "Normal"
 	PriorityHigh   = "High"
 
-	QueueItemEndpoint    = "QueueItem"
-	QueueAddItemEndpoint = "Queues/UiPathODataSvc.AddQueueItem"
+	QueueItemEndpoint         = "QueueItems"
+	QueueAddItemEndpoint      = "Queues/UiPathODataSvc.AddQueueItem"
+	QueueBulkAddItemsEndpoint = "Queues/UiPathODataSvc.BulkAddQueueItems"
+
+	BulkCommitAllOrNothing            = "AllOrNothing"
+	BulkCommitStopOnFirstFailure      = "StopOnFirstFailure"
+	BulkCommitProcessAllIndependently = "ProcessAllIndependently"
 )
 
+// idempotentStoreAttempts is the number of times StoreIdempotent sends the item
+const idempotentStoreAttempts = 3
+
+// ErrMissingReference is returned by StoreIdempotent for items without a reference
+var ErrMissingReference = errors.New("uipath: queue item reference is required")
+
 // QueueItemHandler struct defines what the queue item handler looks like
 type QueueItemHandler struct {
 	Client   *Client
@@ -64,6 +86,25 @@ type QueueItemCreateRequest struct {
 	ItemData QueueItem `json:"itemData"`
 }
 
+// QueueItemBulkCreateRequest defines how the request looks like when creating several queue items at once
+type QueueItemBulkCreateRequest struct {
+	QueueName  string      `json:"queueName"`
+	CommitType string      `json:"commitType"`
+	QueueItems []QueueItem `json:"queueItems"`
+}
+
+// QueueItemBulkFailure defines a queue item the orchestrator did not create
+type QueueItemBulkFailure struct {
+	ItemData     QueueItem `json:"ItemData"`
+	ErrorCode    int       `json:"ErrorCode,omitempty"`
+	ErrorMessage string    `json:"ErrorMessage"`
+}
+
+// QueueItemBulkFailureList defines what the bulk create response looks like
+type QueueItemBulkFailureList struct {
+	Value []QueueItemBulkFailure `json:"value"`
+}
+
 // ProcessingException defines the structure of the queue item exception
 type ProcessingException struct {
 	Reason                  string
@@ -93,6 +134,141 @@ func (q *QueueItemHandler) Store(queueItem QueueItem) (QueueItem, error) {
 	return result, err
 }
 
+// StoreIdempotent stores a queue item at most once per reference within its queue. When Store fails
+// with a duplicate reference, or without telling whether the item was created, the item is looked up
+// by reference and the existing one is returned; Store is only sent again when the lookup tells the
+// item is not found.
+// The queue is given by the QueueDefinitionID or the Name of the item, the missing one is looked up.
+func (q *QueueItemHandler) StoreIdempotent(queueItem QueueItem) (QueueItem, error) {
+	var result QueueItem
+
+	if queueItem.Reference == "" {
+		return result, ErrMissingReference
+	}
+
+	queueDefinitionID, queueName, err := q.queue(queueItem)
+	if err != nil {
+		return result, err
+	}
+
+	// The queue is given by name when adding items
+	queueItem.Name = queueName
+	queueItem.QueueDefinitionID = 0
+
+	info := RequestInfo{Method: "POST", Endpoint: QueueAddItemEndpoint, FolderID: q.FolderId}
+
+	for attempt := 1; ; attempt++ {
+		result, err = q.Store(queueItem)
+		if err == nil {
+			return result, nil
+		}
+
+		if !errors.Is(err, ErrDuplicateReference) && !unknownOutcome(err) {
+			return result, err
+		}
+
+		existing, lookupErr := q.GetByReference(queueDefinitionID, queueItem.Reference)
+		if lookupErr != nil {
+			// The item may have been created, sending Store again could duplicate it
+			return result, fmt.Errorf("%w, looking the queue item up by reference failed: %v", err, lookupErr)
+		}
+
+		if existing.ID != 0 {
+			return existing, nil
+		}
+
+		if errors.Is(err, ErrDuplicateReference) || attempt >= idempotentStoreAttempts {
+			return result, err
+		}
+
+		q.Client.instrumentation().RecordRetry(info, attempt, err)
+
+		if sleepErr := sleepContext(q.Client.Context(), time.Duration(attempt)*500*time.Millisecond); sleepErr != nil {
+			return result, err
+		}
+	}
+}
+
+// GetByReference fetches the latest not deleted queue item of a queue by reference,
+// the queue item is empty when not found
+func (q *QueueItemHandler) GetByReference(queueDefinitionID uint, reference string) (QueueItem, error) {
+	var queueItem QueueItem
+
+	queueItems, _, err := q.List(map[string]string{
+		"$filter": fmt.Sprintf("QueueDefinitionId eq %d and Reference eq %s and Status ne %s",
+			queueDefinitionID, odataLiteral(reference), odataLiteral(QueueItemStatusDeleted)),
+		"$orderby": "Id desc",
+		"$top":     "1",
+	})
+	if err != nil || len(queueItems) < 1 {
+		return queueItem, err
+	}
+
+	return queueItems[0], nil
+}
+
+// unknownOutcome checks if a request failed without telling whether the orchestrator handled it,
+// eg. it timed out or the connection was lost
+func unknownOutcome(err error) bool {
+	var urlError *url.Error
+	var netError net.Error
+
+	return IsRetryable(err) || errors.As(err, &urlError) || (errors.As(err, &netError) && netError.Timeout())
+}
+
+// queue returns the queue id and name of the item, looking up the one it is missing
+func (q *QueueItemHandler) queue(queueItem QueueItem) (uint, string, error) {
+	if queueItem.QueueDefinitionID != 0 && queueItem.Name != "" {
+		return queueItem.QueueDefinitionID, queueItem.Name, nil
+	}
+
+	queues := QueueHandler{Client: q.Client, FolderId: q.FolderId}
+
+	if queueItem.QueueDefinitionID != 0 {
+		queue, err := queues.GetByID(queueItem.QueueDefinitionID)
+
+		return queue.ID, queue.Name, err
+	}
+
+	queue, err := queues.GetByName(queueItem.Name)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if queue.ID == 0 {
+		return 0, "", fmt.Errorf("queue %q: %w", queueItem.Name, ErrNotFound)
+	}
+
+	return queue.ID, queue.Name, nil
+}
+
+// BulkStore creates the queue items of a queue in a single request and returns the ones that failed,
+// the commit type defaults to ProcessAllIndependently
+func (q *QueueItemHandler) BulkStore(queueName string, queueItems []QueueItem, commitType string) ([]QueueItemBulkFailure, error) {
+	var result QueueItemBulkFailureList
+
+	if commitType == "" {
+		commitType = BulkCommitProcessAllIndependently
+	}
+
+	request := QueueItemBulkCreateRequest{
+		QueueName:  queueName,
+		CommitType: commitType,
+		QueueItems: queueItems,
+	}
+
+	url := fmt.Sprintf("%s%s", q.Client.BaseURL, QueueBulkAddItemsEndpoint)
+
+	resp, err := q.Client.SendWithAuthorization("POST", url, request, q.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result.Value, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result.Value, err
+}
+
 // GetByID fetches a queue item by id
 func (q *QueueItemHandler) GetByID(ID uint) (QueueItem, error) {
 	var queueItem QueueItem
@@ -125,6 +301,50 @@ func (q *QueueItemHandler) List(filters map[string]string) ([]QueueItem, int, er
 	return queueItemList.Value, queueItemList.Count, err
 }
 
+// Each calls fn with every queue item matching the odata filter, page by page. Pages are read by
+// increasing Id so items added meanwhile do not shift the pages.
+func (q *QueueItemHandler) Each(filter string, fn func(item QueueItem) error) error {
+	var lastID uint
+
+	for {
+		pageFilter := filter
+		if lastID != 0 {
+			after := fmt.Sprintf("Id gt %d", lastID)
+			if pageFilter == "" {
+				pageFilter = after
+			} else {
+				pageFilter = fmt.Sprintf("(%s) and %s", pageFilter, after)
+			}
+		}
+
+		params := map[string]string{
+			"$orderby": "Id asc",
+			"$top":     strconv.Itoa(listPageSize),
+		}
+
+		if pageFilter != "" {
+			params["$filter"] = pageFilter
+		}
+
+		page, _, err := q.List(params)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range page {
+			if err := fn(item); err != nil {
+				return err
+			}
+
+			lastID = item.ID
+		}
+
+		if len(page) < listPageSize {
+			return nil
+		}
+	}
+}
+
 func (q *QueueItemHandler) buildHeaders() map[string]string {
 	var headers = map[string]string{}
 
@@ -0,0 +1,208 @@
+package uipath
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/comvex-jp/uipath-go/configs"
+	"github.com/jarcoal/httpmock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueueItemTestSuite struct {
+	suite.Suite
+	c *Client
+	h *QueueItemHandler
+}
+
+func (suite *QueueItemTestSuite) SetupTest() {
+	suite.c = &Client{
+		HttpClient: &http.Client{Transport: httpmock.DefaultTransport},
+		BaseURL:    testBaseURL,
+		Cache:      cache.New(5*time.Minute, 10*time.Minute),
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 5*time.Minute)
+
+	suite.h = &QueueItemHandler{Client: suite.c, FolderId: 1}
+
+	httpmock.Activate()
+}
+
+func (suite *QueueItemTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	suite.c.Cache.Flush()
+}
+
+func TestQueueItem(t *testing.T) {
+	suite.Run(t, new(QueueItemTestSuite))
+}
+
+func (suite *QueueItemTestSuite) registerGetByReference(found *QueueItem, filters *[]string) {
+	httpmock.RegisterResponder("GET", testBaseURL+QueueItemEndpoint, func(req *http.Request) (*http.Response, error) {
+		*filters = append(*filters, req.URL.Query().Get("$filter"))
+
+		var list QueueItemList
+		if found.ID != 0 {
+			list.Value = []QueueItem{*found}
+		}
+
+		return httpmock.NewJsonResponse(200, list)
+	})
+}
+
+func (suite *QueueItemTestSuite) TestStoreIdempotentReturnsExistingOnDuplicateReference() {
+	var filters []string
+
+	existing := QueueItem{ID: 42, Reference: "INV-1", Status: QueueItemStatusNew}
+	suite.registerGetByReference(&existing, &filters)
+
+	httpmock.RegisterResponder("POST", testBaseURL+QueueAddItemEndpoint,
+		httpmock.NewStringResponder(409, `{"message": "Error creating Transaction. Duplicate Reference.", "errorCode": 1016}`))
+
+	item, err := suite.h.StoreIdempotent(QueueItem{Name: "Invoices", QueueDefinitionID: 3, Reference: "INV-1"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), uint(42), item.ID)
+	assert.Equal(suite.T(), []string{"QueueDefinitionId eq 3 and Reference eq 'INV-1' and Status ne 'Deleted'"}, filters)
+}
+
+func (suite *QueueItemTestSuite) TestStoreIdempotentRetriesWhenNotCreated() {
+	var filters []string
+	var sent []map[string]interface{}
+
+	suite.registerGetByReference(&QueueItem{}, &filters)
+
+	httpmock.RegisterResponder("GET", testBaseURL+QueueDefinitionEndpoint,
+		httpmock.NewJsonResponderOrPanic(200, QueueDefinitionList{Value: []QueueDefinition{{ID: 3, Name: "Invoices"}}}))
+
+	httpmock.RegisterResponder("POST", testBaseURL+QueueAddItemEndpoint, func(req *http.Request) (*http.Response, error) {
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+
+		sent = append(sent, body["itemData"])
+		if len(sent) == 1 {
+			return httpmock.NewStringResponse(503, `{"message": "Service unavailable"}`), nil
+		}
+
+		return httpmock.NewJsonResponse(201, QueueItem{ID: 43, Reference: "INV-2"})
+	})
+
+	item, err := suite.h.StoreIdempotent(QueueItem{Name: "Invoices", Reference: "INV-2", Priority: PriorityNormal})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), uint(43), item.ID)
+	assert.Len(suite.T(), sent, 2)
+	assert.Len(suite.T(), filters, 1)
+	assert.NotContains(suite.T(), sent[0], "QueueDefinitionId")
+}
+
+func (suite *QueueItemTestSuite) TestStoreIdempotentResolvesQueueName() {
+	var sent map[string]map[string]interface{}
+
+	httpmock.RegisterResponder("GET", testBaseURL+QueueDefinitionEndpoint+"(3)",
+		httpmock.NewJsonResponderOrPanic(200, QueueDefinition{ID: 3, Name: "Invoices"}))
+
+	httpmock.RegisterResponder("POST", testBaseURL+QueueAddItemEndpoint, func(req *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+			return nil, err
+		}
+
+		return httpmock.NewJsonResponse(201, QueueItem{ID: 45, QueueDefinitionID: 3, Reference: "INV-4"})
+	})
+
+	item, err := suite.h.StoreIdempotent(QueueItem{QueueDefinitionID: 3, Reference: "INV-4"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), uint(45), item.ID)
+	assert.Equal(suite.T(), "Invoices", sent["itemData"]["Name"])
+	assert.NotContains(suite.T(), sent["itemData"], "QueueDefinitionId")
+}
+
+func (suite *QueueItemTestSuite) TestStoreIdempotentLooksUpAfterClientTimeout() {
+	var posts int32
+
+	created := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, QueueAddItemEndpoint):
+			// The item is created but the response is only sent after the client gave up
+			if atomic.AddInt32(&posts, 1) == 1 {
+				close(created)
+			}
+
+			_, _ = io.Copy(io.Discard, r.Body)
+
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, QueueItemEndpoint):
+			var list QueueItemList
+
+			select {
+			case <-created:
+				list.Value = []QueueItem{{ID: 44, QueueDefinitionID: 3, Reference: "INV-3"}}
+				list.Count = 1
+			default:
+			}
+
+			_ = json.NewEncoder(w).Encode(list)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		HttpClient: &http.Client{Transport: &http.Transport{}, Timeout: 50 * time.Millisecond},
+		BaseURL:    server.URL + "/odata/",
+		Cache:      cache.New(5*time.Minute, 10*time.Minute),
+	}
+	c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 5*time.Minute)
+
+	h := &QueueItemHandler{Client: c, FolderId: 1}
+
+	item, err := h.StoreIdempotent(QueueItem{Name: "Invoices", QueueDefinitionID: 3, Reference: "INV-3"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), uint(44), item.ID)
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&posts))
+}
+
+func (suite *QueueItemTestSuite) TestGetByIDUsesQueueItemsEntitySet() {
+	httpmock.RegisterResponder("GET", testBaseURL+"QueueItems(5)",
+		httpmock.NewJsonResponderOrPanic(200, QueueItem{ID: 5, Reference: "INV-5"}))
+
+	item, err := suite.h.GetByID(5)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "INV-5", item.Reference)
+}
+
+func (suite *QueueItemTestSuite) TestListUsesQueueItemsEntitySet() {
+	httpmock.RegisterResponder("GET", testBaseURL+"QueueItems",
+		httpmock.NewStringResponder(200, `{"@odata.count":1,"value":[{"Id":5,"Reference":"INV-5"}]}`))
+
+	items, count, err := suite.h.List(map[string]string{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, count)
+	assert.Equal(suite.T(), "INV-5", items[0].Reference)
+}
+
+func (suite *QueueItemTestSuite) TestStoreIdempotentRequiresReference() {
+	_, err := suite.h.StoreIdempotent(QueueItem{Name: "Invoices"})
+
+	assert.ErrorIs(suite.T(), err, ErrMissingReference)
+}
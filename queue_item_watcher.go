@@ -0,0 +1,318 @@
+package uipath
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	defaultWatcherInterval  = 30 * time.Second
+	defaultWatcherOverlap   = 5 * time.Minute
+	defaultWatcherRetention = 24 * time.Hour
+)
+
+// QueueItemEventType is the status a queue item moved to
+type QueueItemEventType string
+
+const (
+	QueueItemEventNew        QueueItemEventType = QueueItemStatusNew
+	QueueItemEventInProgress QueueItemEventType = QueueItemStatusInProgress
+	QueueItemEventSuccessful QueueItemEventType = QueueItemStatusSuccessful
+	QueueItemEventFailed     QueueItemEventType = QueueItemStatusFailed
+	QueueItemEventAbandoned  QueueItemEventType = QueueItemStatusAbandoned
+	QueueItemEventRetried    QueueItemEventType = QueueItemStatusRetried
+	QueueItemEventDeleted    QueueItemEventType = QueueItemStatusDeleted
+)
+
+// QueueItemEvent is emitted when a queue item is seen for the first time or its status changes
+type QueueItemEvent struct {
+	Type QueueItemEventType
+	Item QueueItem
+
+	// PreviousStatus is empty for the items seen for the first time
+	PreviousStatus string
+}
+
+// QueueItemVersion is the last known state of a queue item
+type QueueItemVersion struct {
+	RowVersion string    `json:"rowVersion"`
+	Status     string    `json:"status"`
+	SeenAt     time.Time `json:"seenAt"`
+}
+
+// QueueItemCursor is the state of a watcher, saved after every poll so restarts do not replay events
+type QueueItemCursor struct {
+	// Since is the start of the next polling window
+	Since time.Time                 `json:"since"`
+	Items map[uint]QueueItemVersion `json:"items"`
+}
+
+// CursorStore persists the cursor of a watcher
+type CursorStore interface {
+	Load() (QueueItemCursor, error)
+	Save(cursor QueueItemCursor) error
+}
+
+// FileCursorStore keeps the cursor in a json file
+type FileCursorStore struct {
+	Path string
+}
+
+// QueueItemWatcher polls the items of a queue and emits an event for every status change. Items are
+// tracked by Id and RowVersion, each poll reads the items created, started or ended since the previous
+// poll, the ones in progress, and the tracked ones that left the in progress state without being seen.
+type QueueItemWatcher struct {
+	Handler           *QueueItemHandler
+	QueueDefinitionID uint
+
+	// Interval between polls, it defaults to 30s
+	Interval time.Duration
+
+	// Overlap widens the polling window to absorb clock skew, it defaults to 5m
+	Overlap time.Duration
+
+	// Retention is how long the items not seen are tracked, eg. to notice their retries, it defaults to 24h
+	Retention time.Duration
+
+	// Since is the start of the first window when the cursor is empty, it defaults to now
+	Since time.Time
+
+	// Cursor persists the cursor, it is kept in memory when nil
+	Cursor CursorStore
+
+	// OnError is called when a poll fails, the next poll starts again from the same cursor
+	OnError func(err error)
+
+	cursor QueueItemCursor
+}
+
+// Load reads the cursor, the cursor is empty when the file does not exist
+func (s FileCursorStore) Load() (QueueItemCursor, error) {
+	var cursor QueueItemCursor
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cursor, nil
+	}
+
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(data, &cursor)
+
+	return cursor, err
+}
+
+// Save replaces the file atomically so a crash never leaves it half written
+func (s FileCursorStore) Save(cursor QueueItemCursor) error {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.Path)
+}
+
+// Run polls until ctx is done and sends the events to the channel, the cursor is saved once the
+// events of a poll are delivered. It returns ctx.Err(), or the error loading the cursor.
+func (w *QueueItemWatcher) Run(ctx context.Context, events chan<- QueueItemEvent) error {
+	if err := w.load(); err != nil {
+		return err
+	}
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultWatcherInterval
+	}
+
+	for {
+		if err := w.Poll(ctx, events); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Poll reads the changes once, sends their events and saves the cursor
+func (w *QueueItemWatcher) Poll(ctx context.Context, events chan<- QueueItemEvent) error {
+	if w.cursor.Items == nil {
+		if err := w.load(); err != nil {
+			return err
+		}
+	}
+
+	handler := &QueueItemHandler{Client: w.Handler.Client.WithContext(ctx), FolderId: w.Handler.FolderId}
+	start := time.Now().UTC()
+
+	changes, err := w.changes(handler)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range changes {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		w.track(event.Item, start)
+	}
+
+	w.cursor.Since = start.Add(-w.overlap())
+	w.prune(start)
+
+	if w.Cursor == nil {
+		return nil
+	}
+
+	return w.Cursor.Save(w.cursor)
+}
+
+// changes lists the events of the current window without tracking them yet
+func (w *QueueItemWatcher) changes(handler *QueueItemHandler) ([]QueueItemEvent, error) {
+	var events []QueueItemEvent
+
+	since := w.cursor.Since.UTC().Format(time.RFC3339)
+	filter := fmt.Sprintf("QueueDefinitionId eq %d and (CreationTime ge %s or StartProcessing ge %s or EndProcessing ge %s or Status eq %s)",
+		w.QueueDefinitionID, since, since, since, odataLiteral(QueueItemStatusInProgress))
+
+	seen := map[uint]bool{}
+	retried := map[uint]bool{}
+
+	err := handler.Each(filter, func(item QueueItem) error {
+		seen[item.ID] = true
+
+		if event, ok := w.change(item); ok {
+			events = append(events, event)
+		}
+
+		if item.AncestorID != nil {
+			retried[*item.AncestorID] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return events, err
+	}
+
+	// The tracked items that were retried, or left the in progress state without being seen,
+	// eg. abandoned, are fetched one by one
+	var recheck []uint
+	for id, version := range w.cursor.Items {
+		if seen[id] {
+			continue
+		}
+
+		if version.Status == QueueItemStatusInProgress || (retried[id] && version.Status != QueueItemStatusRetried) {
+			recheck = append(recheck, id)
+		}
+	}
+
+	sort.Slice(recheck, func(i, j int) bool { return recheck[i] < recheck[j] })
+
+	for _, id := range recheck {
+		item, err := handler.GetByID(id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				delete(w.cursor.Items, id)
+				continue
+			}
+
+			return events, err
+		}
+
+		if event, ok := w.change(item); ok {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// change returns the event of the item when it is new or its status changed
+func (w *QueueItemWatcher) change(item QueueItem) (QueueItemEvent, bool) {
+	version, tracked := w.cursor.Items[item.ID]
+
+	if tracked && (version.RowVersion == item.RowVersion || version.Status == item.Status) {
+		if version.RowVersion != item.RowVersion {
+			w.track(item, time.Now().UTC())
+		}
+
+		return QueueItemEvent{}, false
+	}
+
+	return QueueItemEvent{Type: QueueItemEventType(item.Status), Item: item, PreviousStatus: version.Status}, true
+}
+
+func (w *QueueItemWatcher) track(item QueueItem, now time.Time) {
+	w.cursor.Items[item.ID] = QueueItemVersion{RowVersion: item.RowVersion, Status: item.Status, SeenAt: now}
+}
+
+// prune forgets the items that were not seen for the retention, except the ones in progress
+func (w *QueueItemWatcher) prune(now time.Time) {
+	retention := w.Retention
+	if retention <= 0 {
+		retention = defaultWatcherRetention
+	}
+
+	for id, version := range w.cursor.Items {
+		if version.Status != QueueItemStatusInProgress && now.Sub(version.SeenAt) > retention {
+			delete(w.cursor.Items, id)
+		}
+	}
+}
+
+func (w *QueueItemWatcher) load() error {
+	if w.Cursor != nil {
+		cursor, err := w.Cursor.Load()
+		if err != nil {
+			return err
+		}
+
+		w.cursor = cursor
+	}
+
+	if w.cursor.Items == nil {
+		w.cursor.Items = map[uint]QueueItemVersion{}
+	}
+
+	if w.cursor.Since.IsZero() {
+		w.cursor.Since = w.Since
+	}
+
+	if w.cursor.Since.IsZero() {
+		w.cursor.Since = time.Now().UTC()
+	}
+
+	return nil
+}
+
+func (w *QueueItemWatcher) overlap() time.Duration {
+	if w.Overlap <= 0 {
+		return defaultWatcherOverlap
+	}
+
+	return w.Overlap
+}
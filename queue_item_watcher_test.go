@@ -0,0 +1,80 @@
+package uipath
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *QueueItemTestSuite) TestWatcherEmitsTransitionsOnce() {
+	var mu sync.Mutex
+	var listed []QueueItem
+	var filters []string
+	byID := map[uint]QueueItem{}
+
+	httpmock.RegisterResponder("GET", testBaseURL+QueueItemEndpoint, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		filters = append(filters, req.URL.Query().Get("$filter"))
+
+		return httpmock.NewJsonResponse(200, QueueItemList{Value: listed})
+	})
+
+	httpmock.RegisterResponder("GET", `=~^`+testBaseURL+`QueueItems\(\d+\)`, func(req *http.Request) (*http.Response, error) {
+		var id uint
+		fmt.Sscanf(req.URL.Path[strings.LastIndex(req.URL.Path, "("):], "(%d)", &id)
+
+		return httpmock.NewJsonResponse(200, byID[id])
+	})
+
+	store := FileCursorStore{Path: filepath.Join(suite.T().TempDir(), "cursor.json")}
+	poll := func() []QueueItemEvent {
+		watcher := QueueItemWatcher{Handler: suite.h, QueueDefinitionID: 3, Cursor: store}
+		events := make(chan QueueItemEvent, 10)
+
+		assert.Nil(suite.T(), watcher.Poll(context.Background(), events))
+		close(events)
+
+		var result []QueueItemEvent
+		for event := range events {
+			result = append(result, event)
+		}
+
+		return result
+	}
+
+	ancestor := uint(1)
+
+	listed = []QueueItem{{ID: 1, Status: QueueItemStatusNew, RowVersion: "AA"}, {ID: 2, Status: QueueItemStatusInProgress, RowVersion: "AB"}}
+	events := poll()
+	assert.Len(suite.T(), events, 2)
+	assert.Equal(suite.T(), QueueItemEventNew, events[0].Type)
+	assert.Equal(suite.T(), "", events[0].PreviousStatus)
+	assert.Contains(suite.T(), filters[0], "QueueDefinitionId eq 3 and (CreationTime ge ")
+
+	// Restarting from the saved cursor does not replay, progress updates are not transitions
+	listed = []QueueItem{{ID: 1, Status: QueueItemStatusNew, RowVersion: "AA"}, {ID: 2, Status: QueueItemStatusInProgress, RowVersion: "AC"}}
+	assert.Len(suite.T(), poll(), 0)
+
+	// Item 1 failed and was retried by item 3, item 2 was abandoned without being listed
+	byID[1] = QueueItem{ID: 1, Status: QueueItemStatusRetried, RowVersion: "AF"}
+	byID[2] = QueueItem{ID: 2, Status: QueueItemStatusAbandoned, RowVersion: "AE"}
+	listed = []QueueItem{{ID: 3, Status: QueueItemStatusNew, RowVersion: "AD", AncestorID: &ancestor}}
+
+	events = poll()
+	assert.Len(suite.T(), events, 3)
+	assert.Equal(suite.T(), uint(3), events[0].Item.ID)
+	assert.Equal(suite.T(), QueueItemEventRetried, events[1].Type)
+	assert.Equal(suite.T(), QueueItemStatusNew, events[1].PreviousStatus)
+	assert.Equal(suite.T(), QueueItemEventAbandoned, events[2].Type)
+	assert.Equal(suite.T(), QueueItemStatusInProgress, events[2].PreviousStatus)
+
+	assert.Len(suite.T(), poll(), 0)
+}
@@ -0,0 +1,290 @@
+package uipath
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	QueueEventThresholdExceeded  = "ThresholdExceeded"
+	QueueEventThresholdRecovered = "ThresholdRecovered"
+	QueueEventError              = "Error"
+
+	QueueMetricBacklog               = "Backlog"
+	QueueMetricPastDue               = "PastDue"
+	QueueMetricAtRisk                = "AtRisk"
+	QueueMetricAverageProcessingTime = "AverageProcessingTime"
+	QueueMetricFailed                = "Failed"
+
+	// DefaultQueueFailedWindow is how far back QueueHandler.Stats counts the recently failed items
+	DefaultQueueFailedWindow = time.Hour
+)
+
+// queueStatsStatuses are the statuses counted by QueueHandler.Stats
+var queueStatsStatuses = []string{
+	QueueItemStatusNew,
+	QueueItemStatusInProgress,
+	QueueItemStatusFailed,
+	QueueItemStatusSuccessful,
+	QueueItemStatusAbandoned,
+	QueueItemStatusRetried,
+}
+
+// QueueStats is a snapshot of the state of a queue
+type QueueStats struct {
+	QueueDefinitionID uint
+	Name              string
+
+	// CountsByStatus counts the items of every status
+	CountsByStatus map[string]int
+
+	// Backlog counts the items waiting to be processed
+	Backlog    int
+	InProgress int
+
+	// PastDue counts the pending items whose DueDate passed, AtRisk the ones whose RiskSlaDate passed
+	PastDue int
+	AtRisk  int
+
+	// RecentFailed counts the items that failed within FailedWindow, CountsByStatus holds the lifetime total
+	RecentFailed int
+	FailedWindow time.Duration
+
+	AverageProcessingTime           time.Duration
+	AverageSuccessfulProcessingTime time.Duration
+
+	SuccessfulTransactions int
+	ApplicationExceptions  int
+	BusinessExceptions     int
+	LastProcessed          string
+
+	CollectedAt time.Time
+}
+
+// QueueThresholds are the limits a watched queue should stay under, zero values are not checked
+type QueueThresholds struct {
+	MaxBacklog int
+	MaxPastDue int
+	MaxAtRisk  int
+
+	// MaxFailed limits the items that failed within FailedWindow, it defaults to DefaultQueueFailedWindow
+	MaxFailed    int
+	FailedWindow time.Duration
+
+	MaxAverageProcessingTime time.Duration
+}
+
+// QueueEvent is emitted by the watcher when a threshold is exceeded or recovered, or when stats cannot be read
+type QueueEvent struct {
+	Type      string
+	Metric    string
+	Value     float64
+	Threshold float64
+	Stats     QueueStats
+	Err       error
+}
+
+// QueueWatcher periodically evaluates the thresholds of queues and emits events when they change state
+type QueueWatcher struct {
+	Handler *QueueHandler
+
+	// QueueDefinitionIDs are the watched queues
+	QueueDefinitionIDs []uint
+	Thresholds         QueueThresholds
+
+	// Interval between evaluations, it defaults to a minute
+	Interval time.Duration
+
+	OnEvent func(event QueueEvent)
+
+	exceeded map[string]bool
+}
+
+// String describes the event
+func (e QueueEvent) String() string {
+	switch e.Type {
+	case QueueEventError:
+		return fmt.Sprintf("queue %d: %s", e.Stats.QueueDefinitionID, e.Err)
+	case QueueEventThresholdRecovered:
+		return fmt.Sprintf("queue %s: %s recovered at %v, threshold %v", e.Stats.Name, e.Metric, e.Value, e.Threshold)
+	}
+
+	return fmt.Sprintf("queue %s: %s is %v, threshold %v", e.Stats.Name, e.Metric, e.Value, e.Threshold)
+}
+
+// Stats collects the item counts, processing times and sla state of a queue
+func (q *QueueHandler) Stats(queueDefinitionID uint) (QueueStats, error) {
+	return q.stats(queueDefinitionID, DefaultQueueFailedWindow)
+}
+
+// stats collects the stats of a queue, counting the items failed within failedWindow as recently failed
+func (q *QueueHandler) stats(queueDefinitionID uint, failedWindow time.Duration) (QueueStats, error) {
+	now := time.Now().UTC()
+
+	if failedWindow <= 0 {
+		failedWindow = DefaultQueueFailedWindow
+	}
+
+	stats := QueueStats{
+		QueueDefinitionID: queueDefinitionID,
+		CountsByStatus:    map[string]int{},
+		FailedWindow:      failedWindow,
+		CollectedAt:       now,
+	}
+
+	statuses, err := q.ProcessingStatus(map[string]string{
+		"$filter": fmt.Sprintf("QueueDefinitionId eq %d", queueDefinitionID),
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	if len(statuses) > 0 {
+		status := statuses[0]
+
+		stats.Name = status.QueueDefinitionName
+		stats.Backlog = status.ItemsToProcess
+		stats.InProgress = status.ItemsInProgress
+		stats.AverageProcessingTime = secondsDuration(status.ProcessingMeanTime)
+		stats.SuccessfulTransactions = status.SuccessfulTransactionsNo
+		stats.ApplicationExceptions = status.ApplicationExceptionsNo
+		stats.BusinessExceptions = status.BusinessExceptionsNo
+		stats.LastProcessed = status.LastProcessed
+
+		if status.SuccessfulTransactionsNo > 0 {
+			stats.AverageSuccessfulProcessingTime = secondsDuration(status.SuccessfulTransactionsProcessingTime / float64(status.SuccessfulTransactionsNo))
+		}
+	}
+
+	queue := fmt.Sprintf("QueueDefinitionId eq %d", queueDefinitionID)
+	pending := fmt.Sprintf("(Status eq %s or Status eq %s)", odataLiteral(QueueItemStatusNew), odataLiteral(QueueItemStatusInProgress))
+
+	for _, status := range queueStatsStatuses {
+		count, err := q.countItems(fmt.Sprintf("%s and Status eq %s", queue, odataLiteral(status)))
+		if err != nil {
+			return stats, err
+		}
+
+		stats.CountsByStatus[status] = count
+	}
+
+	if stats.PastDue, err = q.countItems(fmt.Sprintf("%s and %s and DueDate lt %s", queue, pending, now.Format(time.RFC3339))); err != nil {
+		return stats, err
+	}
+
+	if stats.AtRisk, err = q.countItems(fmt.Sprintf("%s and %s and RiskSlaDate lt %s", queue, pending, now.Format(time.RFC3339))); err != nil {
+		return stats, err
+	}
+
+	failedSince := now.Add(-failedWindow).Format(time.RFC3339)
+
+	if stats.RecentFailed, err = q.countItems(fmt.Sprintf("%s and Status eq %s and EndProcessing ge %s", queue, odataLiteral(QueueItemStatusFailed), failedSince)); err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// countItems counts the queue items matching the filter without fetching them
+func (q *QueueHandler) countItems(filter string) (int, error) {
+	items := QueueItemHandler{Client: q.Client, FolderId: q.FolderId}
+
+	_, count, err := items.List(map[string]string{
+		"$filter": filter,
+		"$top":    "0",
+		"$count":  "true",
+	})
+
+	return count, err
+}
+
+// Run evaluates the thresholds every interval until ctx is done
+func (w *QueueWatcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	for {
+		w.Check(ctx)
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Check evaluates the thresholds of the watched queues once
+func (w *QueueWatcher) Check(ctx context.Context) {
+	handler := QueueHandler{Client: w.Handler.Client.WithContext(ctx), FolderId: w.Handler.FolderId}
+
+	for _, id := range w.QueueDefinitionIDs {
+		stats, err := handler.stats(id, w.Thresholds.FailedWindow)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.emit(QueueEvent{Type: QueueEventError, Stats: stats, Err: err})
+			}
+
+			continue
+		}
+
+		w.evaluate(stats)
+	}
+}
+
+// evaluate emits an event for every threshold that changed state since the previous evaluation
+func (w *QueueWatcher) evaluate(stats QueueStats) {
+	if w.exceeded == nil {
+		w.exceeded = map[string]bool{}
+	}
+
+	metrics := map[string][2]float64{
+		QueueMetricBacklog:               {float64(stats.Backlog), float64(w.Thresholds.MaxBacklog)},
+		QueueMetricPastDue:               {float64(stats.PastDue), float64(w.Thresholds.MaxPastDue)},
+		QueueMetricAtRisk:                {float64(stats.AtRisk), float64(w.Thresholds.MaxAtRisk)},
+		QueueMetricFailed:                {float64(stats.RecentFailed), float64(w.Thresholds.MaxFailed)},
+		QueueMetricAverageProcessingTime: {stats.AverageProcessingTime.Seconds(), w.Thresholds.MaxAverageProcessingTime.Seconds()},
+	}
+
+	var names []string
+	for name := range metrics {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, threshold := metrics[name][0], metrics[name][1]
+		if threshold <= 0 {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", stats.QueueDefinitionID, name)
+		exceeded := value > threshold
+
+		if exceeded == w.exceeded[key] {
+			continue
+		}
+
+		w.exceeded[key] = exceeded
+
+		event := QueueEvent{Type: QueueEventThresholdExceeded, Metric: name, Value: value, Threshold: threshold, Stats: stats}
+		if !exceeded {
+			event.Type = QueueEventThresholdRecovered
+		}
+
+		w.emit(event)
+	}
+}
+
+func (w *QueueWatcher) emit(event QueueEvent) {
+	if w.OnEvent != nil {
+		w.OnEvent(event)
+	}
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
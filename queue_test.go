@@ -0,0 +1,172 @@
+package uipath
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/comvex-jp/uipath-go/configs"
+	"github.com/jarcoal/httpmock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueueTestSuite struct {
+	suite.Suite
+	c *Client
+	h *QueueHandler
+}
+
+func (suite *QueueTestSuite) SetupTest() {
+	suite.c = &Client{
+		HttpClient: &http.Client{Transport: httpmock.DefaultTransport},
+		BaseURL:    testBaseURL,
+		Cache:      cache.New(5*time.Minute, 10*time.Minute),
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 5*time.Minute)
+
+	suite.h = &QueueHandler{Client: suite.c, FolderId: 1}
+
+	httpmock.Activate()
+}
+
+func (suite *QueueTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	suite.c.Cache.Flush()
+}
+
+func TestQueue(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
+
+// registerStatsResponders serves a queue with the given backlog, number of past due and recently failed items
+func (suite *QueueTestSuite) registerStatsResponders(backlog *int, pastDue *int, recentFailed *int) {
+	httpmock.RegisterResponder("GET", testBaseURL+QueueProcessingStatusEndpoint, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewJsonResponse(200, QueueProcessingStatusList{Value: []QueueProcessingStatus{{
+			QueueDefinitionID:                    3,
+			QueueDefinitionName:                  "Invoices",
+			ItemsToProcess:                       *backlog,
+			ProcessingMeanTime:                   12.5,
+			SuccessfulTransactionsNo:             4,
+			SuccessfulTransactionsProcessingTime: 40,
+		}}})
+	})
+
+	httpmock.RegisterResponder("GET", testBaseURL+QueueItemEndpoint, func(req *http.Request) (*http.Response, error) {
+		filter := req.URL.Query().Get("$filter")
+		count := 0
+
+		switch {
+		case strings.Contains(filter, "DueDate lt"):
+			count = *pastDue
+		case strings.Contains(filter, "Status eq 'Failed' and EndProcessing ge"):
+			count = *recentFailed
+		case strings.HasSuffix(filter, "Status eq 'Failed'"):
+			count = 2
+		case strings.HasSuffix(filter, "Status eq 'New'"):
+			count = *backlog
+		}
+
+		return httpmock.NewJsonResponse(200, QueueItemList{Count: count})
+	})
+}
+
+func (suite *QueueTestSuite) TestStats() {
+	backlog, pastDue, recentFailed := 7, 1, 1
+	suite.registerStatsResponders(&backlog, &pastDue, &recentFailed)
+
+	stats, err := suite.h.Stats(3)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "Invoices", stats.Name)
+	assert.Equal(suite.T(), 7, stats.Backlog)
+	assert.Equal(suite.T(), 7, stats.CountsByStatus[QueueItemStatusNew])
+	assert.Equal(suite.T(), 2, stats.CountsByStatus[QueueItemStatusFailed])
+	assert.Equal(suite.T(), 1, stats.PastDue)
+	assert.Equal(suite.T(), 1, stats.RecentFailed)
+	assert.Equal(suite.T(), DefaultQueueFailedWindow, stats.FailedWindow)
+	assert.Equal(suite.T(), 12500*time.Millisecond, stats.AverageProcessingTime)
+	assert.Equal(suite.T(), 10*time.Second, stats.AverageSuccessfulProcessingTime)
+}
+
+func (suite *QueueTestSuite) TestWatcherEmitsOnStateChanges() {
+	backlog, pastDue, recentFailed := 20, 0, 0
+	suite.registerStatsResponders(&backlog, &pastDue, &recentFailed)
+
+	var events []QueueEvent
+
+	watcher := QueueWatcher{
+		Handler:            suite.h,
+		QueueDefinitionIDs: []uint{3},
+		Thresholds:         QueueThresholds{MaxBacklog: 10, MaxPastDue: 0, MaxFailed: 5},
+		OnEvent:            func(event QueueEvent) { events = append(events, event) },
+	}
+
+	watcher.Check(context.Background())
+	watcher.Check(context.Background())
+
+	assert.Len(suite.T(), events, 1)
+	assert.Equal(suite.T(), QueueEventThresholdExceeded, events[0].Type)
+	assert.Equal(suite.T(), QueueMetricBacklog, events[0].Metric)
+	assert.Equal(suite.T(), "queue Invoices: Backlog is 20, threshold 10", events[0].String())
+
+	backlog = 5
+	watcher.Check(context.Background())
+
+	assert.Len(suite.T(), events, 2)
+	assert.Equal(suite.T(), QueueEventThresholdRecovered, events[1].Type)
+}
+
+func (suite *QueueTestSuite) TestWatcherChecksFailuresWithinWindow() {
+	backlog, pastDue, recentFailed := 0, 0, 3
+	suite.registerStatsResponders(&backlog, &pastDue, &recentFailed)
+
+	var filters []string
+	var events []QueueEvent
+
+	httpmock.RegisterResponder("GET", testBaseURL+QueueItemEndpoint, func(req *http.Request) (*http.Response, error) {
+		filter := req.URL.Query().Get("$filter")
+		filters = append(filters, filter)
+
+		count := 0
+
+		switch {
+		case strings.Contains(filter, "EndProcessing ge"):
+			count = recentFailed
+		case strings.HasSuffix(filter, "Status eq 'Failed'"):
+			count = 50
+		}
+
+		return httpmock.NewJsonResponse(200, QueueItemList{Count: count})
+	})
+
+	watcher := QueueWatcher{
+		Handler:            suite.h,
+		QueueDefinitionIDs: []uint{3},
+		Thresholds:         QueueThresholds{MaxFailed: 2, FailedWindow: 15 * time.Minute},
+		OnEvent:            func(event QueueEvent) { events = append(events, event) },
+	}
+
+	before := time.Now().UTC().Add(-15 * time.Minute)
+	watcher.Check(context.Background())
+
+	assert.Len(suite.T(), events, 1)
+	assert.Equal(suite.T(), QueueMetricFailed, events[0].Metric)
+	assert.Equal(suite.T(), float64(3), events[0].Value)
+	assert.Equal(suite.T(), 50, events[0].Stats.CountsByStatus[QueueItemStatusFailed])
+
+	windowed := filters[len(filters)-1]
+	since, err := time.Parse(time.RFC3339, windowed[strings.LastIndex(windowed, " ")+1:])
+	assert.Nil(suite.T(), err)
+	assert.WithinDuration(suite.T(), before, since, 2*time.Second)
+
+	// the lifetime total stays high, the watcher recovers once the failures leave the window
+	recentFailed = 0
+	watcher.Check(context.Background())
+
+	assert.Len(suite.T(), events, 2)
+	assert.Equal(suite.T(), QueueEventThresholdRecovered, events[1].Type)
+}
@@ -0,0 +1,90 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	QueueStartTransactionEndpoint       = "Queues/UiPathODataSvc.StartTransaction"
+	QueueSetTransactionResultEndpoint   = "UiPathODataSvc.SetTransactionResult"
+	QueueSetTransactionProgressEndpoint = "UiPathODataSvc.SetTransactionProgress"
+
+	ExceptionTypeBusiness    = "BusinessException"
+	ExceptionTypeApplication = "ApplicationException"
+)
+
+// TransactionData defines which queue to start a transaction on
+type TransactionData struct {
+	Name            string                 `json:"Name"`
+	RobotIdentifier string                 `json:"RobotIdentifier,omitempty"`
+	SpecificContent map[string]interface{} `json:"SpecificContent,omitempty"`
+	Reference       string                 `json:"Reference,omitempty"`
+
+	// ReferenceFilterOption is Equals or StartsWith, it applies to Reference
+	ReferenceFilterOption string `json:"ReferenceFilterOption,omitempty"`
+}
+
+// TransactionStartRequest defines how the request looks like when starting a transaction
+type TransactionStartRequest struct {
+	TransactionData TransactionData `json:"transactionData"`
+}
+
+// TransactionResult defines the outcome of a transaction
+type TransactionResult struct {
+	IsSuccessful        bool                   `json:"IsSuccessful"`
+	ProcessingException *TransactionException  `json:"ProcessingException,omitempty"`
+	Output              map[string]interface{} `json:"Output,omitempty"`
+}
+
+// TransactionException defines why a transaction failed, Type is ExceptionTypeBusiness or ExceptionTypeApplication
+type TransactionException struct {
+	Reason  string `json:"Reason"`
+	Details string `json:"Details,omitempty"`
+	Type    string `json:"Type"`
+}
+
+// TransactionResultRequest defines how the request looks like when ending a transaction
+type TransactionResultRequest struct {
+	TransactionResult TransactionResult `json:"transactionResult"`
+}
+
+// TransactionProgressRequest defines how the request looks like when reporting the progress of a transaction
+type TransactionProgressRequest struct {
+	Progress string `json:"progress"`
+}
+
+// StartTransaction takes the next item of the queue and sets it in progress,
+// the queue item is empty when there is nothing to process
+func (q *QueueItemHandler) StartTransaction(transactionData TransactionData) (QueueItem, error) {
+	var queueItem QueueItem
+
+	url := fmt.Sprintf("%s%s", q.Client.BaseURL, QueueStartTransactionEndpoint)
+
+	resp, err := q.Client.SendWithAuthorization("POST", url, TransactionStartRequest{TransactionData: transactionData}, q.buildHeaders(), map[string]string{})
+	if err != nil || len(resp) == 0 {
+		return queueItem, err
+	}
+
+	err = json.Unmarshal(resp, &queueItem)
+
+	return queueItem, err
+}
+
+// SetTransactionResult ends the transaction of a queue item
+func (q *QueueItemHandler) SetTransactionResult(ID uint, result TransactionResult) error {
+	url := fmt.Sprintf("%s%s(%d)/%s", q.Client.BaseURL, QueueItemEndpoint, ID, QueueSetTransactionResultEndpoint)
+
+	_, err := q.Client.SendWithAuthorization("POST", url, TransactionResultRequest{TransactionResult: result}, q.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// SetTransactionProgress reports the progress of a queue item in progress
+func (q *QueueItemHandler) SetTransactionProgress(ID uint, progress string) error {
+	url := fmt.Sprintf("%s%s(%d)/%s", q.Client.BaseURL, QueueItemEndpoint, ID, QueueSetTransactionProgressEndpoint)
+
+	_, err := q.Client.SendWithAuthorization("POST", url, TransactionProgressRequest{Progress: progress}, q.buildHeaders(), map[string]string{})
+
+	return err
+}
@@ -0,0 +1,258 @@
+package uipath
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderRetryAfter         = "Retry-After"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimit defines a token bucket allowing Rate requests per second with bursts of up to Burst requests
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiterConfig defines the limits applied to the api calls of a client
+type RateLimiterConfig struct {
+	// Default applies to the endpoint families without their own limit, a zero rate means unlimited
+	Default RateLimit
+
+	// Families holds the limits per endpoint family, the first segment of the endpoint, eg. QueueItems or Jobs
+	Families map[string]RateLimit
+
+	// MaxInFlight caps the number of concurrent api calls, zero means unlimited
+	MaxInFlight int
+}
+
+// RateLimiter limits the rate and concurrency of the api calls of all the handlers sharing a client.
+// It also holds back an endpoint family when the orchestrator answers with Retry-After or X-RateLimit headers.
+type RateLimiter struct {
+	config RateLimiterConfig
+
+	mu           sync.Mutex
+	buckets      map[string]*tokenBucket
+	blockedUntil map[string]time.Time
+	inFlight     chan struct{}
+	now          func() time.Time
+}
+
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a rate limiter, set it on Client.RateLimiter
+func NewRateLimiter(config RateLimiterConfig) *RateLimiter {
+	l := &RateLimiter{
+		config:       config,
+		buckets:      map[string]*tokenBucket{},
+		blockedUntil: map[string]time.Time{},
+		now:          time.Now,
+	}
+
+	if config.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, config.MaxInFlight)
+	}
+
+	return l
+}
+
+// EndpointFamily returns the family of an endpoint template, eg. QueueItems for QueueItems({key})
+func EndpointFamily(endpoint string) string {
+	if i := strings.IndexAny(endpoint, "/("); i >= 0 {
+		return endpoint[:i]
+	}
+
+	return endpoint
+}
+
+// Wait blocks until the family can be called and a concurrency slot is free, the returned release
+// func frees the slot once the call is done
+func (l *RateLimiter) Wait(ctx context.Context, family string) (func(), error) {
+	for {
+		delay := l.reserve(family)
+		if delay <= 0 {
+			break
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return func() {}, err
+		}
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.inFlight <- struct{}{}:
+		var once sync.Once
+
+		return func() { once.Do(func() { <-l.inFlight }) }, nil
+	case <-ctx.Done():
+		return func() {}, ctx.Err()
+	}
+}
+
+// Observe holds back the family when the response asks to slow down
+func (l *RateLimiter) Observe(family string, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	now := l.now()
+	var until time.Time
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if d, ok := parseRetryAfter(resp.Header.Get(HeaderRetryAfter), now); ok {
+			until = now.Add(d)
+		}
+	}
+
+	if resp.Header.Get(HeaderRateLimitRemaining) == "0" {
+		if reset, ok := parseRateLimitReset(resp.Header.Get(HeaderRateLimitReset), now); ok && reset.After(until) {
+			until = reset
+		}
+	}
+
+	if until.IsZero() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.blockedUntil[family]) {
+		l.blockedUntil[family] = until
+	}
+}
+
+// Middleware applies the limiter to every api call going through the chain
+func (l *RateLimiter) Middleware() Middleware {
+	return func(next Invoker) Invoker {
+		return func(call *Call) (*http.Response, []byte, error) {
+			family := EndpointFamily(call.Endpoint)
+
+			release, err := l.Wait(call.Request.Context(), family)
+			if err != nil {
+				return nil, nil, err
+			}
+			defer release()
+
+			resp, body, err := next(call)
+
+			l.Observe(family, resp)
+
+			return resp, body, err
+		}
+	}
+}
+
+// reserve takes a token for the family and returns how long to wait when none is available
+func (l *RateLimiter) reserve(family string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if until, ok := l.blockedUntil[family]; ok {
+		if now.Before(until) {
+			return until.Sub(now)
+		}
+
+		delete(l.blockedUntil, family)
+	}
+
+	bucket := l.bucket(family, now)
+	if bucket == nil {
+		return 0
+	}
+
+	elapsed := now.Sub(bucket.last).Seconds()
+	bucket.tokens = math.Min(float64(bucket.limit.Burst), bucket.tokens+elapsed*bucket.limit.Rate)
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - bucket.tokens) / bucket.limit.Rate * float64(time.Second))
+}
+
+func (l *RateLimiter) bucket(family string, now time.Time) *tokenBucket {
+	if bucket, ok := l.buckets[family]; ok {
+		return bucket
+	}
+
+	limit, ok := l.config.Families[family]
+	if !ok {
+		limit = l.config.Default
+	}
+
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	bucket := &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: now}
+	l.buckets[family] = bucket
+
+	return bucket
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
+
+// parseRateLimitReset reads the reset header either as a unix timestamp or as a number of seconds
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if seconds > 1000000000 {
+		return time.Unix(seconds, 0), true
+	}
+
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+package uipath
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	l := NewRateLimiter(RateLimiterConfig{
+		Families: map[string]RateLimit{"QueueItems": {Rate: 2, Burst: 2}},
+	})
+	l.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), l.reserve("QueueItems"))
+	assert.Equal(t, time.Duration(0), l.reserve("QueueItems"))
+	assert.Equal(t, 500*time.Millisecond, l.reserve("QueueItems"))
+
+	// Families without a limit and no default are not limited
+	assert.Equal(t, time.Duration(0), l.reserve("Assets"))
+
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve("QueueItems"))
+}
+
+func TestRateLimiterObservesRetryAfter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	l := NewRateLimiter(RateLimiterConfig{})
+	l.now = func() time.Time { return now }
+
+	l.Observe("Jobs", &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{HeaderRetryAfter: []string{"3"}}})
+
+	header := http.Header{}
+	header.Set(HeaderRateLimitRemaining, "0")
+	header.Set(HeaderRateLimitReset, "1700000010")
+	l.Observe("Assets", &http.Response{StatusCode: http.StatusOK, Header: header})
+
+	assert.Equal(t, 3*time.Second, l.reserve("Jobs"))
+	assert.Equal(t, 10*time.Second, l.reserve("Assets"))
+	assert.Equal(t, time.Duration(0), l.reserve("QueueItems"))
+
+	now = now.Add(3 * time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve("Jobs"))
+}
+
+func TestRateLimiterMaxInFlight(t *testing.T) {
+	l := NewRateLimiter(RateLimiterConfig{MaxInFlight: 1})
+
+	release, err := l.Wait(context.Background(), "Jobs")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = l.Wait(ctx, "Assets")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+
+	release, err = l.Wait(context.Background(), "Assets")
+	assert.Nil(t, err)
+	release()
+}
+
+func TestEndpointFamily(t *testing.T) {
+	assert.Equal(t, "QueueItems", EndpointFamily("QueueItems({key})"))
+	assert.Equal(t, "Queues", EndpointFamily(QueueAddItemEndpoint))
+	assert.Equal(t, "Jobs", EndpointFamily("Jobs"))
+}
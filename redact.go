@@ -0,0 +1,138 @@
+package uipath
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RedactedValue replaces secrets when printing models
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeys are the lower cased header, query, form and json keys whose values are never logged
+var sensitiveKeys = map[string]bool{
+	"authorization":           true,
+	"cookie":                  true,
+	"set-cookie":              true,
+	"client_secret":           true,
+	"refresh_token":           true,
+	"access_token":            true,
+	"id_token":                true,
+	"password":                true,
+	"credentialpassword":      true,
+	"secretvalue":             true,
+	"secret":                  true,
+	"applicationsecret":       true,
+	"userkey":                 true,
+	"additionalconfiguration": true,
+}
+
+// IsSensitiveKey reports whether the values of a header, query, form or json key are redacted
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+func redact(value string) string {
+	if value == "" {
+		return value
+	}
+
+	return RedactedValue
+}
+
+// RedactHeaders copies the headers with the sensitive values replaced
+func RedactHeaders(headers http.Header) http.Header {
+	result := http.Header{}
+
+	for k, values := range headers {
+		if IsSensitiveKey(k) {
+			result[k] = []string{RedactedValue}
+			continue
+		}
+
+		result[k] = values
+	}
+
+	return result
+}
+
+// RedactBody returns a json or form encoded body with the sensitive values replaced, json bodies are
+// compacted with sorted keys and form bodies sorted so equal bodies are redacted the same way
+func RedactBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err == nil {
+		redacted, err := json.Marshal(redactJSONValue(value))
+		if err == nil {
+			return string(redacted)
+		}
+	}
+
+	if !strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return string(body)
+	}
+
+	if form, err := url.ParseQuery(string(body)); err == nil {
+		for k := range form {
+			if IsSensitiveKey(k) {
+				form.Set(k, RedactedValue)
+			}
+		}
+
+		return form.Encode()
+	}
+
+	return string(body)
+}
+
+// sensitiveValueKeys are the keys holding the value of the assets whose whole value is a secret, like
+// the passwords of database connection strings, by value type
+var sensitiveValueKeys = map[string][]string{
+	ValueTypeDBConnectionString: {"StringValue", "Value"},
+}
+
+func redactJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		valueType, _ := v["ValueType"].(string)
+		valueKeys := sensitiveValueKeys[valueType]
+
+		for k, field := range v {
+			if IsSensitiveKey(k) || containsKey(valueKeys, k) {
+				if s, ok := field.(string); ok && s == "" {
+					continue
+				}
+
+				v[k] = RedactedValue
+				continue
+			}
+
+			v[k] = redactJSONValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSONValue(item)
+		}
+	}
+
+	return value
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	return false
+}
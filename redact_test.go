@@ -0,0 +1,17 @@
+package uipath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactBodyHidesCredentialStoreConfiguration(t *testing.T) {
+	body := []byte(`{"Name":"vault","Type":"HashicorpVault","AdditionalConfiguration":"{\"RoleId\":\"r\",\"SecretId\":\"s3cr3t\"}"}`)
+
+	redacted := RedactBody(body, "application/json")
+
+	assert.NotContains(t, redacted, "s3cr3t")
+	assert.Contains(t, redacted, `"AdditionalConfiguration":"[REDACTED]"`)
+	assert.Contains(t, redacted, `"Name":"vault"`)
+}
@@ -0,0 +1,103 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const ReleaseEndpoint = "Releases"
+
+// ReleaseHandler struct defines what the release handler looks like
+type ReleaseHandler struct {
+	Client   *Client
+	FolderId uint
+}
+
+// Release struct defines what the release, or process, model looks like
+type Release struct {
+	ID                                 uint   `json:"Id,omitempty"`
+	Key                                string `json:"Key,omitempty"`
+	Name                               string `json:"Name"`
+	ProcessKey                         string `json:"ProcessKey,omitempty"`
+	ProcessVersion                     string `json:"ProcessVersion,omitempty"`
+	IsLatestVersion                    bool   `json:"IsLatestVersion,omitempty"`
+	Description                        string `json:"Description,omitempty"`
+	InputArguments                     string `json:"InputArguments,omitempty"`
+	EntryPointPath                     string `json:"EntryPointPath,omitempty"`
+	OrganizationUnitID                 uint   `json:"OrganizationUnitId,omitempty"`
+	OrganizationUnitFullyQualifiedName string `json:"OrganizationUnitFullyQualifiedName,omitempty"`
+}
+
+// ReleaseList defines what the release list model looks like
+type ReleaseList struct {
+	Count int       `json:"@odata.count"`
+	Value []Release `json:"value"`
+}
+
+// GetByID fetches a release by id
+func (r *ReleaseHandler) GetByID(ID uint) (Release, error) {
+	var release Release
+
+	url := fmt.Sprintf("%s%s(%d)", r.Client.BaseURL, ReleaseEndpoint, ID)
+
+	resp, err := r.Client.SendWithAuthorization("GET", url, nil, r.buildHeaders(), map[string]string{})
+	if err != nil {
+		return release, err
+	}
+
+	err = json.Unmarshal(resp, &release)
+
+	return release, err
+}
+
+// GetByName fetches a release by name, the release is empty when not found
+func (r *ReleaseHandler) GetByName(name string) (Release, error) {
+	var releaseList ReleaseList
+	var release Release
+
+	params := url.Values{}
+	params.Set("$filter", fmt.Sprintf("Name eq %s", odataLiteral(name)))
+
+	url := fmt.Sprintf("%s%s?%s", r.Client.BaseURL, ReleaseEndpoint, params.Encode())
+
+	resp, err := r.Client.SendWithAuthorization("GET", url, nil, r.buildHeaders(), map[string]string{})
+	if err != nil {
+		return release, err
+	}
+
+	if err = json.Unmarshal(resp, &releaseList); err != nil {
+		return release, err
+	}
+
+	if len(releaseList.Value) < 1 {
+		return release, nil
+	}
+
+	return releaseList.Value[0], nil
+}
+
+// List fetches a list of releases that can be filtered using query parameters
+func (r *ReleaseHandler) List(filters map[string]string) ([]Release, int, error) {
+	var releaseList ReleaseList
+
+	url := fmt.Sprintf("%s%s", r.Client.BaseURL, ReleaseEndpoint)
+
+	resp, err := r.Client.SendWithAuthorization("GET", url, nil, r.buildHeaders(), filters)
+	if err != nil {
+		return releaseList.Value, releaseList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &releaseList)
+
+	return releaseList.Value, releaseList.Count, err
+}
+
+func (r *ReleaseHandler) buildHeaders() map[string]string {
+	var headers = map[string]string{}
+
+	headers[HeaderOrganizationUnitId] = strconv.Itoa(int(r.FolderId))
+
+	return headers
+}
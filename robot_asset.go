@@ -0,0 +1,93 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	AssetGetRobotAssetEndpoint           = "Assets/UiPath.Server.Configuration.OData.GetRobotAsset"
+	AssetGetRobotAssetByRobotKeyEndpoint = "Assets/UiPath.Server.Configuration.OData.GetRobotAssetByNameForRobotKey"
+	AssetSetRobotAssetByRobotKeyEndpoint = "Assets/UiPath.Server.Configuration.OData.SetRobotAssetByRobotKey"
+)
+
+// RobotAssetRequest defines how the request looks like when fetching an asset for a robot key
+type RobotAssetRequest struct {
+	AssetName string `json:"assetName"`
+	RobotKey  string `json:"robotKey"`
+}
+
+// RobotAssetSetRequest defines how the request looks like when setting an asset value for a robot key
+type RobotAssetSetRequest struct {
+	RobotKey   string `json:"robotKey"`
+	RobotAsset Asset  `json:"robotAsset"`
+}
+
+// KeyValuePair defines the key value items returned with robot assets
+type KeyValuePair struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+// robotAsset is the asset as returned to robots, its key value list is made of pairs instead of strings
+type robotAsset struct {
+	Asset
+	KeyValueList []KeyValuePair `json:"KeyValueList,omitempty"`
+}
+
+// GetRobotAsset fetches the asset with the value resolved for the robot, the same way workflows read it
+func (a *AssetHandler) GetRobotAsset(robotID string, name string) (Asset, error) {
+	url := fmt.Sprintf("%s%s(robotId=%s,assetName=%s)", a.Client.BaseURL, AssetGetRobotAssetEndpoint, odataPathLiteral(robotID), odataPathLiteral(name))
+
+	resp, err := a.Client.SendWithAuthorization("GET", url, nil, a.buildHeaders(), map[string]string{})
+	if err != nil {
+		return Asset{}, err
+	}
+
+	return decodeRobotAsset(resp)
+}
+
+// GetRobotAssetByRobotKey fetches the asset with the value and credential resolved for the robot key
+func (a *AssetHandler) GetRobotAssetByRobotKey(robotKey string, name string) (Asset, error) {
+	request := RobotAssetRequest{
+		AssetName: name,
+		RobotKey:  robotKey,
+	}
+
+	url := fmt.Sprintf("%s%s", a.Client.BaseURL, AssetGetRobotAssetByRobotKeyEndpoint)
+
+	resp, err := a.Client.SendWithAuthorization("POST", url, request, a.buildHeaders(), map[string]string{})
+	if err != nil {
+		return Asset{}, err
+	}
+
+	return decodeRobotAsset(resp)
+}
+
+// SetRobotAssetByRobotKey sets the value the asset has for the robot key
+func (a *AssetHandler) SetRobotAssetByRobotKey(robotKey string, asset Asset) error {
+	request := RobotAssetSetRequest{
+		RobotKey:   robotKey,
+		RobotAsset: asset,
+	}
+
+	url := fmt.Sprintf("%s%s", a.Client.BaseURL, AssetSetRobotAssetByRobotKeyEndpoint)
+
+	_, err := a.Client.SendWithAuthorization("POST", url, request, a.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+func decodeRobotAsset(resp []byte) (Asset, error) {
+	var result robotAsset
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return result.Asset, err
+	}
+
+	for _, pair := range result.KeyValueList {
+		result.Asset.KeyValueList = append(result.Asset.KeyValueList, fmt.Sprintf("%s=%s", pair.Key, pair.Value))
+	}
+
+	return result.Asset, nil
+}
@@ -0,0 +1,141 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	RoleTypeMixed  = "Mixed"
+	RoleTypeTenant = "Tenant"
+	RoleTypeFolder = "Folder"
+
+	RoleEndpoint         = "Roles"
+	RoleSetUsersEndpoint = "UiPath.Server.Configuration.OData.SetUsers"
+)
+
+// RoleHandler struct defines what the role handler looks like
+type RoleHandler struct {
+	Client   *Client
+	FolderId uint
+}
+
+// Role struct defines what the role model looks like
+type Role struct {
+	ID          uint         `json:"Id,omitempty"`
+	Name        string       `json:"Name"`
+	DisplayName string       `json:"DisplayName,omitempty"`
+	Type        string       `json:"Type,omitempty"`
+	Groups      string       `json:"Groups,omitempty"`
+	IsStatic    bool         `json:"IsStatic,omitempty"`
+	IsEditable  bool         `json:"IsEditable,omitempty"`
+	Permissions []Permission `json:"Permissions,omitempty"`
+}
+
+// Permission struct defines a permission granted by a role
+type Permission struct {
+	ID        uint   `json:"Id,omitempty"`
+	Name      string `json:"Name"`
+	IsGranted bool   `json:"IsGranted"`
+	RoleID    uint   `json:"RoleId,omitempty"`
+	Scope     string `json:"Scope,omitempty"`
+}
+
+// RoleList struct defines what the role list looks like
+type RoleList struct {
+	Count int    `json:"@odata.count"`
+	Value []Role `json:"value"`
+}
+
+// RoleSetUsersRequest defines how the request looks like when changing the users of a role
+type RoleSetUsersRequest struct {
+	AddedUserIDs   []uint `json:"addedUserIds"`
+	RemovedUserIDs []uint `json:"removedUserIds"`
+}
+
+// GetByID fetches the role by id
+func (r *RoleHandler) GetByID(ID uint) (Role, error) {
+	var role Role
+
+	url := fmt.Sprintf("%s%s(%d)", r.Client.BaseURL, RoleEndpoint, ID)
+
+	resp, err := r.Client.SendWithAuthorization("GET", url, nil, r.buildHeaders(), map[string]string{"$expand": "Permissions"})
+	if err != nil {
+		return role, err
+	}
+
+	err = json.Unmarshal(resp, &role)
+
+	return role, err
+}
+
+// List fetches a list of roles that can be filtered using query parameters
+func (r *RoleHandler) List(filters map[string]string) ([]Role, int, error) {
+	var roleList RoleList
+
+	url := fmt.Sprintf("%s%s", r.Client.BaseURL, RoleEndpoint)
+
+	resp, err := r.Client.SendWithAuthorization("GET", url, nil, r.buildHeaders(), filters)
+	if err != nil {
+		return roleList.Value, roleList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &roleList)
+
+	return roleList.Value, roleList.Count, err
+}
+
+// Store creates a role with its permission set on the orchestrator
+func (r *RoleHandler) Store(role Role) (Role, error) {
+	var result Role
+
+	url := fmt.Sprintf("%s%s", r.Client.BaseURL, RoleEndpoint)
+
+	resp, err := r.Client.SendWithAuthorization("POST", url, role, r.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result, err
+}
+
+// Update updates a role and its permission set
+func (r *RoleHandler) Update(role Role) (Role, error) {
+	url := fmt.Sprintf("%s%s(%d)", r.Client.BaseURL, RoleEndpoint, role.ID)
+
+	_, err := r.Client.SendWithAuthorization("PUT", url, role, r.buildHeaders(), map[string]string{})
+	if err != nil {
+		return role, err
+	}
+
+	return r.GetByID(role.ID)
+}
+
+// DeleteByID deletes a role by id
+func (r *RoleHandler) DeleteByID(ID uint) error {
+	url := fmt.Sprintf("%s%s(%d)", r.Client.BaseURL, RoleEndpoint, ID)
+
+	_, err := r.Client.SendWithAuthorization("DELETE", url, nil, r.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// SetUsers adds and removes users from a role
+func (r *RoleHandler) SetUsers(roleID uint, addedUserIDs []uint, removedUserIDs []uint) error {
+	request := RoleSetUsersRequest{
+		AddedUserIDs:   addedUserIDs,
+		RemovedUserIDs: removedUserIDs,
+	}
+
+	url := fmt.Sprintf("%s%s(%d)/%s", r.Client.BaseURL, RoleEndpoint, roleID, RoleSetUsersEndpoint)
+
+	_, err := r.Client.SendWithAuthorization("POST", url, request, r.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+func (r *RoleHandler) buildHeaders() map[string]string {
+	return tenantHeaders(r.FolderId)
+}
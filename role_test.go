@@ -0,0 +1,76 @@
+package uipath
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/comvex-jp/uipath-go/configs"
+	"github.com/jarcoal/httpmock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleTestSuite struct {
+	suite.Suite
+	c *Client
+	h *RoleHandler
+}
+
+func (suite *RoleTestSuite) SetupTest() {
+	suite.c = &Client{
+		HttpClient: &http.Client{Transport: httpmock.DefaultTransport},
+		BaseURL:    testBaseURL,
+		Cache:      cache.New(5*time.Minute, 10*time.Minute),
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 5*time.Minute)
+
+	suite.h = &RoleHandler{Client: suite.c}
+
+	httpmock.Activate()
+}
+
+func (suite *RoleTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	suite.c.Cache.Flush()
+}
+
+func TestRole(t *testing.T) {
+	suite.Run(t, new(RoleTestSuite))
+}
+
+func (suite *RoleTestSuite) TestGetByIDExpandsPermissions() {
+	var expand string
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s%s(8)", testBaseURL, RoleEndpoint), func(req *http.Request) (*http.Response, error) {
+		expand = req.URL.Query().Get("$expand")
+
+		return httpmock.NewStringResponse(200, `{"Id":8,"Name":"Reviewer","Permissions":[{"Name":"Queues.View","IsGranted":true}]}`), nil
+	})
+
+	role, err := suite.h.GetByID(8)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "Permissions", expand)
+	assert.Equal(suite.T(), "Queues.View", role.Permissions[0].Name)
+}
+
+func (suite *RoleTestSuite) TestSetUsers() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s%s(8)/%s", testBaseURL, RoleEndpoint, RoleSetUsersEndpoint), func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := suite.h.SetUsers(8, []uint{5}, []uint{6, 7})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), map[string]interface{}{
+		"addedUserIds":   []interface{}{float64(5)},
+		"removedUserIds": []interface{}{float64(6), float64(7)},
+	}, sent)
+}
@@ -0,0 +1,308 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	TaskTypeForm               = "FormTask"
+	TaskTypeExternal           = "ExternalTask"
+	TaskTypeDocumentValidation = "DocumentValidationTask"
+
+	TaskStatusUnassigned = "Unassigned"
+	TaskStatusPending    = "Pending"
+	TaskStatusCompleted  = "Completed"
+
+	TaskEndpoint             = "Tasks"
+	TaskAssignEndpoint       = "Tasks/UiPath.Server.Configuration.OData.AssignTasks"
+	TaskReassignEndpoint     = "Tasks/UiPath.Server.Configuration.OData.ReassignTasks"
+	TaskUnassignEndpoint     = "Tasks/UiPath.Server.Configuration.OData.UnassignTasks"
+	TaskCatalogEndpoint      = "TaskCatalogs"
+	TaskFormCreateEndpoint   = "forms/TaskForms/CreateFormTask"
+	TaskFormGetEndpoint      = "forms/TaskForms/GetTaskFormById"
+	TaskFormCompleteEndpoint = "forms/TaskForms/CompleteTask"
+)
+
+// TaskHandler struct defines what the action center task handler looks like
+type TaskHandler struct {
+	Client   *Client
+	FolderId uint
+}
+
+// Task struct defines what the task model looks like
+type Task struct {
+	ID                   uint     `json:"Id,omitempty"`
+	Key                  string   `json:"Key,omitempty"`
+	Title                string   `json:"Title"`
+	Type                 string   `json:"Type,omitempty"`
+	Priority             string   `json:"Priority,omitempty"`
+	Status               string   `json:"Status,omitempty"`
+	Action               string   `json:"Action,omitempty"`
+	AssignedToUserID     *uint    `json:"AssignedToUserId,omitempty"`
+	TaskCatalogName      string   `json:"TaskCatalogName,omitempty"`
+	ExternalTag          string   `json:"ExternalTag,omitempty"`
+	IsDeleted            bool     `json:"IsDeleted,omitempty"`
+	IsCompleted          bool     `json:"IsCompleted,omitempty"`
+	CreationTime         string   `json:"CreationTime,omitempty"`
+	LastAssignedTime     string   `json:"LastAssignedTime,omitempty"`
+	CompletionTime       string   `json:"CompletionTime,omitempty"`
+	LastModificationTime string   `json:"LastModificationTime,omitempty"`
+	OrganizationUnitID   uint     `json:"OrganizationUnitId,omitempty"`
+	Tags                 []string `json:"Tags,omitempty"`
+}
+
+// TaskList struct defines what the task list looks like
+type TaskList struct {
+	Count int    `json:"@odata.count"`
+	Value []Task `json:"value"`
+}
+
+// FormTask struct defines what the form task model with its form data looks like
+type FormTask struct {
+	Task
+	FormLayout   map[string]interface{} `json:"FormLayout,omitempty"`
+	FormLayoutID *uint                  `json:"FormLayoutId,omitempty"`
+	Data         map[string]interface{} `json:"Data,omitempty"`
+}
+
+// FormTaskCreateRequest defines how the request looks like when creating a form task
+type FormTaskCreateRequest struct {
+	Title           string                 `json:"Title"`
+	Priority        string                 `json:"Priority,omitempty"`
+	TaskCatalogName string                 `json:"TaskCatalogName,omitempty"`
+	ExternalTag     string                 `json:"ExternalTag,omitempty"`
+	Tags            []string               `json:"Tags,omitempty"`
+	FormLayout      map[string]interface{} `json:"FormLayout,omitempty"`
+	FormLayoutID    *uint                  `json:"FormLayoutId,omitempty"`
+	Data            interface{}            `json:"Data,omitempty"`
+}
+
+// TaskCompleteRequest defines how the request looks like when completing a form task
+type TaskCompleteRequest struct {
+	TaskID uint        `json:"taskId"`
+	Action string      `json:"action"`
+	Data   interface{} `json:"data"`
+}
+
+// TaskAssignment defines the user a task is assigned to
+type TaskAssignment struct {
+	TaskID          uint   `json:"TaskId"`
+	UserID          uint   `json:"UserId,omitempty"`
+	UserNameOrEmail string `json:"UserNameOrEmail,omitempty"`
+}
+
+// TaskAssignmentResult defines the outcome of a single task assignment
+type TaskAssignmentResult struct {
+	TaskID       uint   `json:"TaskId"`
+	UserID       uint   `json:"UserId"`
+	ErrorCode    int    `json:"ErrorCode"`
+	ErrorMessage string `json:"ErrorMessage"`
+}
+
+// TaskAssignmentResultList defines what the task assignment result list looks like
+type TaskAssignmentResultList struct {
+	Value []TaskAssignmentResult `json:"value"`
+}
+
+// TaskAssignRequest defines how the request looks like when assigning tasks
+type TaskAssignRequest struct {
+	TaskAssignments []TaskAssignment `json:"taskAssignments"`
+}
+
+// TaskUnassignRequest defines how the request looks like when unassigning tasks
+type TaskUnassignRequest struct {
+	TaskIDs []uint `json:"taskIds"`
+}
+
+// TaskCatalog struct defines what the task catalog model looks like
+type TaskCatalog struct {
+	ID                   uint   `json:"Id,omitempty"`
+	Name                 string `json:"Name"`
+	Description          string `json:"Description,omitempty"`
+	Encrypted            bool   `json:"Encrypted,omitempty"`
+	LastModificationTime string `json:"LastModificationTime,omitempty"`
+	CreationTime         string `json:"CreationTime,omitempty"`
+}
+
+// TaskCatalogList struct defines what the task catalog list looks like
+type TaskCatalogList struct {
+	Count int           `json:"@odata.count"`
+	Value []TaskCatalog `json:"value"`
+}
+
+// DecodeData decodes the form data of the task into v
+func (f FormTask) DecodeData(v interface{}) error {
+	data, err := json.Marshal(f.Data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// GetByID fetches the task by id
+func (t *TaskHandler) GetByID(ID uint) (Task, error) {
+	var task Task
+
+	url := fmt.Sprintf("%s%s(%d)", t.Client.BaseURL, TaskEndpoint, ID)
+
+	resp, err := t.Client.SendWithAuthorization("GET", url, nil, t.buildHeaders(), map[string]string{})
+	if err != nil {
+		return task, err
+	}
+
+	err = json.Unmarshal(resp, &task)
+
+	return task, err
+}
+
+// List fetches a list of tasks that can be filtered using query parameters
+func (t *TaskHandler) List(filters map[string]string) ([]Task, int, error) {
+	var taskList TaskList
+
+	url := fmt.Sprintf("%s%s", t.Client.BaseURL, TaskEndpoint)
+
+	resp, err := t.Client.SendWithAuthorization("GET", url, nil, t.buildHeaders(), filters)
+	if err != nil {
+		return taskList.Value, taskList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &taskList)
+
+	return taskList.Value, taskList.Count, err
+}
+
+// Assign assigns tasks to users and returns the assignments that failed
+func (t *TaskHandler) Assign(assignments []TaskAssignment) ([]TaskAssignmentResult, error) {
+	return t.sendAssignments(TaskAssignEndpoint, TaskAssignRequest{TaskAssignments: assignments})
+}
+
+// Reassign moves already assigned tasks to other users and returns the assignments that failed
+func (t *TaskHandler) Reassign(assignments []TaskAssignment) ([]TaskAssignmentResult, error) {
+	return t.sendAssignments(TaskReassignEndpoint, TaskAssignRequest{TaskAssignments: assignments})
+}
+
+// Unassign removes the users assigned to the tasks and returns the tasks that failed
+func (t *TaskHandler) Unassign(taskIDs []uint) ([]TaskAssignmentResult, error) {
+	return t.sendAssignments(TaskUnassignEndpoint, TaskUnassignRequest{TaskIDs: taskIDs})
+}
+
+// CreateFormTask creates a form task on the action center
+func (t *TaskHandler) CreateFormTask(request FormTaskCreateRequest) (FormTask, error) {
+	var result FormTask
+
+	url := fmt.Sprintf("%s%s", t.Client.rootURL(), TaskFormCreateEndpoint)
+
+	resp, err := t.Client.SendWithAuthorization("POST", url, request, t.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result, err
+}
+
+// GetFormTask fetches a form task together with its form layout and data
+func (t *TaskHandler) GetFormTask(taskID uint) (FormTask, error) {
+	var result FormTask
+
+	url := fmt.Sprintf("%s%s", t.Client.rootURL(), TaskFormGetEndpoint)
+	queryParams := map[string]string{
+		"taskId": strconv.Itoa(int(taskID)),
+	}
+
+	resp, err := t.Client.SendWithAuthorization("GET", url, nil, t.buildHeaders(), queryParams)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result, err
+}
+
+// CompleteFormTask completes a form task with the given action and form data
+func (t *TaskHandler) CompleteFormTask(taskID uint, action string, data interface{}) error {
+	request := TaskCompleteRequest{
+		TaskID: taskID,
+		Action: action,
+		Data:   data,
+	}
+
+	url := fmt.Sprintf("%s%s", t.Client.rootURL(), TaskFormCompleteEndpoint)
+
+	_, err := t.Client.SendWithAuthorization("POST", url, request, t.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// ListCatalogs fetches a list of task catalogs that can be filtered using query parameters
+func (t *TaskHandler) ListCatalogs(filters map[string]string) ([]TaskCatalog, int, error) {
+	var catalogList TaskCatalogList
+
+	url := fmt.Sprintf("%s%s", t.Client.BaseURL, TaskCatalogEndpoint)
+
+	resp, err := t.Client.SendWithAuthorization("GET", url, nil, t.buildHeaders(), filters)
+	if err != nil {
+		return catalogList.Value, catalogList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &catalogList)
+
+	return catalogList.Value, catalogList.Count, err
+}
+
+// StoreCatalog creates a task catalog on the orchestrator
+func (t *TaskHandler) StoreCatalog(catalog TaskCatalog) (TaskCatalog, error) {
+	var result TaskCatalog
+
+	url := fmt.Sprintf("%s%s", t.Client.BaseURL, TaskCatalogEndpoint)
+
+	resp, err := t.Client.SendWithAuthorization("POST", url, catalog, t.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result, err
+}
+
+// DeleteCatalogByID deletes a task catalog by id
+func (t *TaskHandler) DeleteCatalogByID(ID uint) error {
+	url := fmt.Sprintf("%s%s(%d)", t.Client.BaseURL, TaskCatalogEndpoint, ID)
+
+	_, err := t.Client.SendWithAuthorization("DELETE", url, nil, t.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+func (t *TaskHandler) sendAssignments(endpoint string, body interface{}) ([]TaskAssignmentResult, error) {
+	var resultList TaskAssignmentResultList
+
+	url := fmt.Sprintf("%s%s", t.Client.BaseURL, endpoint)
+
+	resp, err := t.Client.SendWithAuthorization("POST", url, body, t.buildHeaders(), map[string]string{})
+	if err != nil {
+		return resultList.Value, err
+	}
+
+	if len(resp) == 0 {
+		return resultList.Value, nil
+	}
+
+	err = json.Unmarshal(resp, &resultList)
+
+	return resultList.Value, err
+}
+
+func (t *TaskHandler) buildHeaders() map[string]string {
+	var headers = map[string]string{}
+
+	headers[HeaderOrganizationUnitId] = strconv.Itoa(int(t.FolderId))
+
+	return headers
+}
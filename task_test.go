@@ -0,0 +1,170 @@
+package uipath
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/comvex-jp/uipath-go/configs"
+	"github.com/jarcoal/httpmock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// testRootURL is where the form task endpoints live, outside of odata
+var testRootURL = strings.TrimSuffix(testBaseURL, "odata/")
+
+type TaskTestSuite struct {
+	suite.Suite
+	c *Client
+	h *TaskHandler
+}
+
+func (suite *TaskTestSuite) SetupTest() {
+	suite.c = &Client{
+		HttpClient: &http.Client{Transport: httpmock.DefaultTransport},
+		BaseURL:    testBaseURL,
+		Cache:      cache.New(5*time.Minute, 10*time.Minute),
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 5*time.Minute)
+
+	suite.h = &TaskHandler{Client: suite.c, FolderId: 4}
+
+	httpmock.Activate()
+}
+
+func (suite *TaskTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	suite.c.Cache.Flush()
+}
+
+func TestTask(t *testing.T) {
+	suite.Run(t, new(TaskTestSuite))
+}
+
+// decodeBody decodes the json body of a request sent to a responder
+func decodeBody(req *http.Request, v interface{}) {
+	body, _ := ioutil.ReadAll(req.Body)
+	json.Unmarshal(body, v)
+}
+
+func (suite *TaskTestSuite) TestList() {
+	var query, folder string
+
+	httpmock.RegisterResponder("GET", testBaseURL+TaskEndpoint, func(req *http.Request) (*http.Response, error) {
+		query = req.URL.Query().Get("$filter")
+		folder = req.Header.Get(HeaderOrganizationUnitId)
+
+		return httpmock.NewStringResponse(200, `{"@odata.count":2,"value":[{"Id":1,"Title":"Approve invoice"},{"Id":2,"Title":"Check order"}]}`), nil
+	})
+
+	tasks, count, err := suite.h.List(map[string]string{"$filter": "Status eq 'Unassigned'"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, count)
+	assert.Equal(suite.T(), "Check order", tasks[1].Title)
+	assert.Equal(suite.T(), "Status eq 'Unassigned'", query)
+	assert.Equal(suite.T(), "4", folder)
+}
+
+func (suite *TaskTestSuite) TestAssign() {
+	var sent map[string][]map[string]interface{}
+
+	httpmock.RegisterResponder("POST", testBaseURL+TaskAssignEndpoint, func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(200, `{"value":[{"TaskId":2,"UserId":0,"ErrorCode":1002,"ErrorMessage":"User not found"}]}`), nil
+	})
+
+	failed, err := suite.h.Assign([]TaskAssignment{{TaskID: 1, UserID: 7}, {TaskID: 2, UserNameOrEmail: "robot@example.com"}})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []TaskAssignmentResult{{TaskID: 2, ErrorCode: 1002, ErrorMessage: "User not found"}}, failed)
+	assert.Equal(suite.T(), []map[string]interface{}{
+		{"TaskId": float64(1), "UserId": float64(7)},
+		{"TaskId": float64(2), "UserNameOrEmail": "robot@example.com"},
+	}, sent["taskAssignments"])
+}
+
+func (suite *TaskTestSuite) TestUnassignAcceptsEmptyResponse() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("POST", testBaseURL+TaskUnassignEndpoint, func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	failed, err := suite.h.Unassign([]uint{3, 5})
+
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), failed)
+	assert.Equal(suite.T(), map[string]interface{}{"taskIds": []interface{}{float64(3), float64(5)}}, sent)
+}
+
+func (suite *TaskTestSuite) TestCreateFormTask() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("POST", testRootURL+TaskFormCreateEndpoint, func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(200, `{"Id":9,"Title":"Approve invoice","Type":"FormTask","Data":{"Amount":12}}`), nil
+	})
+
+	task, err := suite.h.CreateFormTask(FormTaskCreateRequest{
+		Title:           "Approve invoice",
+		TaskCatalogName: "Invoices",
+		Data:            map[string]interface{}{"Amount": 12},
+	})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), uint(9), task.ID)
+	assert.Equal(suite.T(), float64(12), task.Data["Amount"])
+	assert.Equal(suite.T(), map[string]interface{}{
+		"Title":           "Approve invoice",
+		"TaskCatalogName": "Invoices",
+		"Data":            map[string]interface{}{"Amount": float64(12)},
+	}, sent)
+}
+
+func (suite *TaskTestSuite) TestGetFormTask() {
+	var taskID string
+
+	httpmock.RegisterResponder("GET", testRootURL+TaskFormGetEndpoint, func(req *http.Request) (*http.Response, error) {
+		taskID = req.URL.Query().Get("taskId")
+
+		return httpmock.NewStringResponse(200, `{"Id":9,"Title":"Approve invoice","Data":{"Approved":true}}`), nil
+	})
+
+	task, err := suite.h.GetFormTask(9)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "9", taskID)
+
+	var data struct{ Approved bool }
+	assert.Nil(suite.T(), task.DecodeData(&data))
+	assert.True(suite.T(), data.Approved)
+}
+
+func (suite *TaskTestSuite) TestCompleteFormTask() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("POST", testRootURL+TaskFormCompleteEndpoint, func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := suite.h.CompleteFormTask(9, "Approve", map[string]interface{}{"Comment": "ok"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), map[string]interface{}{
+		"taskId": float64(9),
+		"action": "Approve",
+		"data":   map[string]interface{}{"Comment": "ok"},
+	}, sent)
+}
@@ -0,0 +1,193 @@
+package uipathtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/comvex-jp/uipath-go"
+)
+
+// assetValueTypes are the value types accepted when creating assets
+var assetValueTypes = map[string]bool{
+	uipath.ValueTypeText:               true,
+	uipath.ValueTypeInteger:            true,
+	uipath.ValueTypeBool:               true,
+	uipath.ValueTypeCredential:         true,
+	uipath.ValueTypeSecret:             true,
+	uipath.ValueTypeDBConnectionString: true,
+}
+
+// storedAsset is an asset and the folder it belongs to
+type storedAsset struct {
+	folderID uint
+	asset    uipath.Asset
+}
+
+// AddAsset creates an asset in a folder, its id is set when empty
+func (s *Server) AddAsset(folderID uint, asset uipath.Asset) uipath.Asset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addAsset(folderID, asset)
+}
+
+// Assets returns the assets of a folder, including their credential passwords and secret values
+func (s *Server) Assets(folderID uint) []uipath.Asset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.folderAssets(folderID)
+}
+
+func (s *Server) addAsset(folderID uint, asset uipath.Asset) uipath.Asset {
+	if asset.ID == 0 {
+		asset.ID = s.nextID(uipath.AssetEndpoint)
+	}
+
+	if asset.ValueScope == "" {
+		asset.ValueScope = uipath.ValueScopeGlobal
+	}
+
+	asset.CanBeDeleted = true
+	asset.FolderCount = 1
+
+	s.assets = append(s.assets, storedAsset{folderID: folderID, asset: asset})
+
+	return asset
+}
+
+func (s *Server) folderAssets(folderID uint) []uipath.Asset {
+	assets := []uipath.Asset{}
+
+	for _, stored := range s.assets {
+		if stored.folderID == folderID {
+			assets = append(assets, stored.asset)
+		}
+	}
+
+	return assets
+}
+
+func (s *Server) assetIndex(folderID uint, ID uint) int {
+	for i, stored := range s.assets {
+		if stored.folderID == folderID && stored.asset.ID == ID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) serveAssets(w http.ResponseWriter, r *http.Request, req route, body []byte) *apiError {
+	if req.action != "" {
+		return notFoundRoute(r)
+	}
+
+	if !req.hasKey {
+		switch r.Method {
+		case http.MethodGet:
+			assets := s.folderAssets(req.folderID)
+			for i := range assets {
+				assets[i] = hideAssetValues(assets[i])
+			}
+
+			return list(w, r, uipath.AssetEndpoint, assets)
+		case http.MethodPost:
+			return s.createAsset(w, req, body)
+		}
+
+		return methodNotAllowed(r)
+	}
+
+	i := s.assetIndex(req.folderID, req.key)
+	if i < 0 {
+		return notFound("Asset")
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, hideAssetValues(s.assets[i].asset))
+	case http.MethodPut:
+		var asset uipath.Asset
+
+		if err := decode(body, &asset); err != nil {
+			return err
+		}
+
+		if err := s.validateAsset(req.folderID, req.key, asset); err != nil {
+			return err
+		}
+
+		asset.ID = req.key
+		asset.CanBeDeleted = true
+		asset.FolderCount = 1
+
+		// The robot and user values left out of the request are kept
+		if asset.RobotValues == nil {
+			asset.RobotValues = s.assets[i].asset.RobotValues
+		}
+
+		if asset.UserValues == nil {
+			asset.UserValues = s.assets[i].asset.UserValues
+		}
+
+		s.assets[i].asset = asset
+		writeJSON(w, http.StatusOK, hideAssetValues(asset))
+	case http.MethodDelete:
+		s.assets = append(s.assets[:i], s.assets[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r)
+	}
+
+	return nil
+}
+
+func (s *Server) createAsset(w http.ResponseWriter, req route, body []byte) *apiError {
+	var asset uipath.Asset
+
+	if err := decode(body, &asset); err != nil {
+		return err
+	}
+
+	if err := s.validateAsset(req.folderID, 0, asset); err != nil {
+		return err
+	}
+
+	asset.ID = 0
+
+	writeJSON(w, http.StatusCreated, hideAssetValues(s.addAsset(req.folderID, asset)))
+
+	return nil
+}
+
+// validateAsset checks an asset sent to the server, ID is the asset being updated
+func (s *Server) validateAsset(folderID uint, ID uint, asset uipath.Asset) *apiError {
+	if strings.TrimSpace(asset.Name) == "" {
+		return invalid("The Name field is required.")
+	}
+
+	if !assetValueTypes[asset.ValueType] {
+		return invalid("Invalid asset value type: %s.", asset.ValueType)
+	}
+
+	if asset.ValueScope != "" && asset.ValueScope != uipath.ValueScopeGlobal && asset.ValueScope != uipath.ValueScopePerRobot {
+		return invalid("Invalid asset value scope: %s.", asset.ValueScope)
+	}
+
+	for _, stored := range s.assets {
+		if stored.folderID == folderID && stored.asset.ID != ID && strings.EqualFold(stored.asset.Name, asset.Name) {
+			return conflict(uipath.ErrorCodeNameAlreadyExists, "An asset with the same name already exists.")
+		}
+	}
+
+	return nil
+}
+
+// hideAssetValues removes the values the orchestrator never returns
+func hideAssetValues(asset uipath.Asset) uipath.Asset {
+	asset.CredentialPassword = ""
+	asset.SecretValue = ""
+
+	return asset
+}
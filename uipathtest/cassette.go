@@ -0,0 +1,319 @@
+package uipathtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/comvex-jp/uipath-go"
+)
+
+// ErrUnmatchedRequest is returned by the replayer for the requests that were not recorded
+var ErrUnmatchedRequest = errors.New("uipathtest: no recorded interaction matches the request")
+
+// Cassette is a list of recorded interactions with the orchestrator
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it got
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a scrubbed request
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a scrubbed response
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http client that sends the requests with Client and records them in a cassette file,
+// the file is written after every interaction. Tokens, secrets and credential values are scrubbed.
+type Recorder struct {
+	Client uipath.HttpClientInterface
+	Path   string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// Replayer is an http client answering the requests with the responses of a cassette. A request is
+// answered by the first unused interaction with the same method, path, query and body, requests
+// without one fail with ErrUnmatchedRequest.
+type Replayer struct {
+	// Repeat answers a request again with its last matching interaction once they are all used,
+	// eg. for the requests polling a job
+	Repeat bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// UnmatchedRequestError describes a request the replayer could not answer
+type UnmatchedRequestError struct {
+	Request RecordedRequest
+
+	// Candidates are the interactions with the same method and path
+	Candidates []RecordedRequest
+}
+
+// NewRecorder creates a recorder writing to path, the client defaults to http.DefaultClient
+func NewRecorder(path string, client uipath.HttpClientInterface) *Recorder {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Recorder{Client: client, Path: path}
+}
+
+// LoadCassette reads a cassette file
+func LoadCassette(path string) (Cassette, error) {
+	var cassette Cassette
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cassette, err
+	}
+
+	err = json.Unmarshal(data, &cassette)
+
+	return cassette, err
+}
+
+// Save writes the cassette, the file is replaced atomically
+func (c Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// NewReplayer creates a replayer for a cassette file
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}, nil
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	message := fmt.Sprintf("%s: %s %s", ErrUnmatchedRequest, e.Request.Method, e.Request.URL)
+	if e.Request.Body != "" {
+		message += " with body " + e.Request.Body
+	}
+
+	if len(e.Candidates) == 0 {
+		return message + ", nothing was recorded for this method and path"
+	}
+
+	message += ", recorded for this method and path:"
+	for _, candidate := range e.Candidates {
+		message += fmt.Sprintf("\n  %s %s", candidate.URL, candidate.Body)
+	}
+
+	return message
+}
+
+// Unwrap makes the error match ErrUnmatchedRequest
+func (e *UnmatchedRequestError) Unwrap() error {
+	return ErrUnmatchedRequest
+}
+
+// Do sends the request and records it with its response
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: scrubRequest(req, body),
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     uipath.RedactHeaders(resp.Header),
+			Body:       uipath.RedactBody(respBody, resp.Header.Get("Content-Type")),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+
+	return resp, r.cassette.Save(r.Path)
+}
+
+// Cassette returns the interactions recorded so far
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Cassette{Interactions: append([]Interaction{}, r.cassette.Interactions...)}
+}
+
+// Do answers the request with its recorded response
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := scrubRequest(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	var candidates []RecordedRequest
+
+	for i, interaction := range r.cassette.Interactions {
+		if !samePath(interaction.Request, recorded) {
+			continue
+		}
+
+		candidates = append(candidates, interaction.Request)
+
+		if !sameQueryAndBody(interaction.Request, recorded) {
+			continue
+		}
+
+		last = i
+
+		if !r.used[i] {
+			r.used[i] = true
+			return replay(req, interaction.Response), nil
+		}
+	}
+
+	if r.Repeat && last >= 0 {
+		return replay(req, r.cassette.Interactions[last].Response), nil
+	}
+
+	return nil, &UnmatchedRequestError{Request: recorded, Candidates: candidates}
+}
+
+// Unused returns the interactions that did not answer any request
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+// readRequestBody reads the body of a request and puts it back for the next reader
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func replay(req *http.Request, recorded RecordedResponse) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+func samePath(a, b RecordedRequest) bool {
+	aURL, errA := url.Parse(a.URL)
+	bURL, errB := url.Parse(b.URL)
+
+	return errA == nil && errB == nil && a.Method == b.Method && aURL.Path == bURL.Path
+}
+
+// sameQueryAndBody compares the queries without their order, the bodies are already normalized by uipath.RedactBody
+func sameQueryAndBody(a, b RecordedRequest) bool {
+	aURL, errA := url.Parse(a.URL)
+	bURL, errB := url.Parse(b.URL)
+
+	return errA == nil && errB == nil && aURL.Query().Encode() == bURL.Query().Encode() && a.Body == b.Body
+}
+
+func scrubRequest(req *http.Request, body []byte) RecordedRequest {
+	u := *req.URL
+
+	query := u.Query()
+	for k := range query {
+		if uipath.IsSensitiveKey(k) {
+			query.Set(k, uipath.RedactedValue)
+		}
+	}
+
+	u.RawQuery = query.Encode()
+
+	return RecordedRequest{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: uipath.RedactHeaders(req.Header),
+		Body:   uipath.RedactBody(body, req.Header.Get("Content-Type")),
+	}
+}
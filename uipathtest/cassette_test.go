@@ -0,0 +1,91 @@
+package uipathtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exercise runs the calls recorded and replayed by the cassette tests
+func exercise(t *testing.T, c *uipath.Client, folderID uint) {
+	assets := uipath.AssetHandler{Client: c, FolderId: folderID}
+
+	stored, err := assets.Store(uipath.NewCredentialAsset("Login", "robot", "p4ss"))
+	require.NoError(t, err)
+
+	found, err := assets.GetByName("Login")
+	require.NoError(t, err)
+	assert.Equal(t, stored.ID, found.ID)
+
+	warehouse, err := assets.Store(uipath.NewDBConnectionStringAsset("Warehouse", "Server=db;User Id=robot;Password=d8p4ss"))
+	require.NoError(t, err)
+
+	assert.Equal(t, uipath.ValueTypeDBConnectionString, warehouse.ValueType)
+
+	_, err = assets.GetByID(warehouse.ID + 1)
+	assert.ErrorIs(t, err, uipath.ErrNotFound)
+
+	items := uipath.QueueItemHandler{Client: c, FolderId: folderID}
+
+	item, err := items.Store(uipath.QueueItem{Name: "Orders", Reference: "ORD-1", SpecificContent: map[string]interface{}{"Amount": 12}})
+	require.NoError(t, err)
+
+	started, err := items.StartTransaction(uipath.TransactionData{Name: "Orders"})
+	require.NoError(t, err)
+	assert.Equal(t, item.ID, started.ID)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	s := NewServer()
+	s.AddQueue(s.DefaultFolder.ID, uipath.QueueDefinition{Name: "Orders"})
+
+	recorder := NewRecorder(path, s.server.Client())
+
+	c, err := s.Client(uipath.WithHTTPClient(recorder))
+	require.NoError(t, err)
+
+	exercise(t, c, s.DefaultFolder.ID)
+	s.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Len(t, recorder.Cassette().Interactions, 7)
+	assert.NotContains(t, string(data), DefaultApplicationSecret)
+	assert.NotContains(t, string(data), "p4ss")
+	assert.NotContains(t, string(data), "Password=")
+	assert.NotContains(t, string(data), "Bearer ")
+	assert.Contains(t, string(data), uipath.RedactedValue)
+
+	replayer, err := NewReplayer(path)
+	require.NoError(t, err)
+
+	c, err = s.Client(uipath.WithHTTPClient(replayer))
+	require.NoError(t, err)
+
+	exercise(t, c, s.DefaultFolder.ID)
+	assert.Empty(t, replayer.Unused())
+
+	_, err = (&uipath.AssetHandler{Client: c, FolderId: s.DefaultFolder.ID}).GetByName("Login")
+
+	var unmatched *UnmatchedRequestError
+	require.True(t, errors.As(err, &unmatched))
+	assert.ErrorIs(t, err, ErrUnmatchedRequest)
+	assert.Len(t, unmatched.Candidates, 1)
+
+	replayer.Repeat = true
+
+	found, err := (&uipath.AssetHandler{Client: c, FolderId: s.DefaultFolder.ID}).GetByName("Login")
+	require.NoError(t, err)
+	assert.Equal(t, "Login", found.Name)
+
+	_, err = (&uipath.AssetHandler{Client: c, FolderId: s.DefaultFolder.ID}).GetByName("Other")
+	assert.ErrorIs(t, err, ErrUnmatchedRequest)
+}
@@ -0,0 +1,140 @@
+package uipathtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/google/uuid"
+)
+
+// AddFolder creates a folder, its id, key and fully qualified name are set when empty
+func (s *Server) AddFolder(folder uipath.Folder) uipath.Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addFolder(folder)
+}
+
+// Folders returns the folders
+func (s *Server) Folders() []uipath.Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]uipath.Folder{}, s.folders...)
+}
+
+func (s *Server) addFolder(folder uipath.Folder) uipath.Folder {
+	if folder.ID == 0 {
+		folder.ID = s.nextID("Folders")
+	}
+
+	if folder.Key == "" {
+		folder.Key = uuid.NewString()
+	}
+
+	if folder.FolderType == "" {
+		folder.FolderType = uipath.FolderTypeStandard
+	}
+
+	if folder.FullyQualifiedName == "" {
+		folder.FullyQualifiedName = folder.DisplayName
+
+		if folder.ParentID != nil {
+			if i := s.folderIndex(*folder.ParentID); i >= 0 {
+				folder.ParentKey = s.folders[i].Key
+				folder.FullyQualifiedName = s.folders[i].FullyQualifiedName + "/" + folder.DisplayName
+			}
+		}
+	}
+
+	s.folders = append(s.folders, folder)
+
+	return folder
+}
+
+func (s *Server) folderIndex(ID uint) int {
+	for i, folder := range s.folders {
+		if folder.ID == ID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) serveFolders(w http.ResponseWriter, r *http.Request, req route, body []byte) *apiError {
+	if req.action != "" {
+		return notFoundRoute(r)
+	}
+
+	if !req.hasKey {
+		switch r.Method {
+		case http.MethodGet:
+			return list(w, r, "Folders", s.folders)
+		case http.MethodPost:
+			return s.createFolder(w, body)
+		}
+
+		return methodNotAllowed(r)
+	}
+
+	i := s.folderIndex(req.key)
+	if i < 0 {
+		return &apiError{statusCode: http.StatusNotFound, errorCode: uipath.ErrorCodeFolderNotFound, message: "Folder does not exist."}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.folders[i])
+	case http.MethodDelete:
+		for _, folder := range s.folders {
+			if folder.ParentID != nil && *folder.ParentID == req.key {
+				return invalid("Folder %s has subfolders and cannot be deleted.", s.folders[i].DisplayName)
+			}
+		}
+
+		s.folders = append(s.folders[:i], s.folders[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r)
+	}
+
+	return nil
+}
+
+func (s *Server) createFolder(w http.ResponseWriter, body []byte) *apiError {
+	var folder uipath.Folder
+
+	if err := decode(body, &folder); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(folder.DisplayName) == "" {
+		return invalid("The DisplayName field is required.")
+	}
+
+	if folder.ParentID != nil && s.folderIndex(*folder.ParentID) < 0 {
+		return &apiError{statusCode: http.StatusNotFound, errorCode: uipath.ErrorCodeFolderNotFound, message: "Parent folder does not exist."}
+	}
+
+	for _, existing := range s.folders {
+		if strings.EqualFold(existing.DisplayName, folder.DisplayName) && sameParent(existing.ParentID, folder.ParentID) {
+			return conflict(uipath.ErrorCodeNameAlreadyExists, "A folder with the same name already exists.")
+		}
+	}
+
+	folder.ID, folder.Key, folder.FullyQualifiedName, folder.ParentKey = 0, "", "", ""
+
+	writeJSON(w, http.StatusCreated, s.addFolder(folder))
+
+	return nil
+}
+
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,223 @@
+package uipathtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/google/uuid"
+)
+
+// jobStartAction starts the jobs of a release
+const jobStartAction = "UiPathODataSvc.StartJobs"
+
+// AddRelease creates a release, or process, in a folder, its id and key are set when empty
+func (s *Server) AddRelease(folderID uint, release uipath.Release) uipath.Release {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if release.ID == 0 {
+		release.ID = s.nextID(uipath.ReleaseEndpoint)
+	}
+
+	if release.Key == "" {
+		release.Key = uuid.NewString()
+	}
+
+	if release.ProcessKey == "" {
+		release.ProcessKey = release.Name
+	}
+
+	release.OrganizationUnitID = folderID
+	if i := s.folderIndex(folderID); i >= 0 {
+		release.OrganizationUnitFullyQualifiedName = s.folders[i].FullyQualifiedName
+	}
+
+	s.releases = append(s.releases, release)
+
+	return release
+}
+
+// Jobs returns the jobs
+func (s *Server) Jobs() []uipath.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]uipath.Job{}, s.jobs...)
+}
+
+// SetJobState moves a job to a state like a robot would, the start and end times follow the state.
+// It returns false when the job does not exist.
+func (s *Server) SetJobState(ID uint, state string, info string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.jobs {
+		job := &s.jobs[i]
+		if job.ID != ID {
+			continue
+		}
+
+		job.State = state
+		job.Info = info
+
+		if state != uipath.JobStatePending && job.StartTime == "" {
+			job.StartTime = s.timestamp()
+		}
+
+		if job.IsFinal() {
+			job.EndTime = s.timestamp()
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (s *Server) serveReleases(w http.ResponseWriter, r *http.Request, req route) *apiError {
+	if req.action != "" {
+		return notFoundRoute(r)
+	}
+
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(r)
+	}
+
+	releases := []uipath.Release{}
+	for _, release := range s.releases {
+		if release.OrganizationUnitID == req.folderID {
+			releases = append(releases, release)
+		}
+	}
+
+	if !req.hasKey {
+		return list(w, r, uipath.ReleaseEndpoint, releases)
+	}
+
+	for _, release := range releases {
+		if release.ID == req.key {
+			writeJSON(w, http.StatusOK, release)
+			return nil
+		}
+	}
+
+	return notFound("Process")
+}
+
+func (s *Server) serveJobs(w http.ResponseWriter, r *http.Request, req route, body []byte) *apiError {
+	if req.action == jobStartAction && !req.hasKey {
+		if r.Method != http.MethodPost {
+			return methodNotAllowed(r)
+		}
+
+		return s.startJobsAction(w, req, body)
+	}
+
+	if req.action != "" {
+		return notFoundRoute(r)
+	}
+
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(r)
+	}
+
+	jobs := []uipath.Job{}
+	for _, job := range s.jobs {
+		if job.OrganizationUnitID == req.folderID {
+			jobs = append(jobs, job)
+		}
+	}
+
+	if !req.hasKey {
+		return list(w, r, uipath.JobEndpoint, jobs)
+	}
+
+	for _, job := range jobs {
+		if job.ID == req.key {
+			writeJSON(w, http.StatusOK, job)
+			return nil
+		}
+	}
+
+	return notFound("Job")
+}
+
+// startJobsAction creates pending jobs, they stay pending until SetJobState moves them
+func (s *Server) startJobsAction(w http.ResponseWriter, req route, body []byte) *apiError {
+	var request uipath.StartJobsRequest
+
+	if err := decode(body, &request); err != nil {
+		return err
+	}
+
+	info := request.StartInfo
+
+	var release *uipath.Release
+	for i := range s.releases {
+		if s.releases[i].OrganizationUnitID == req.folderID && strings.EqualFold(s.releases[i].Key, info.ReleaseKey) {
+			release = &s.releases[i]
+		}
+	}
+
+	if release == nil {
+		return notFound("Process")
+	}
+
+	count := 0
+
+	switch info.Strategy {
+	case uipath.JobStrategyModernJobsCount:
+		count = info.JobsCount
+	case uipath.JobStrategySpecific:
+		count = len(info.RobotIds)
+	default:
+		return invalid("Invalid strategy: %s.", info.Strategy)
+	}
+
+	if count < 1 {
+		return invalid("At least one job must be started.")
+	}
+
+	jobPriority := info.JobPriority
+	if jobPriority == "" {
+		jobPriority = uipath.PriorityNormal
+	}
+
+	source := info.Source
+	if source == "" {
+		source = "Manual"
+	}
+
+	batch := uuid.NewString()
+	jobs := []uipath.Job{}
+
+	for i := 0; i < count; i++ {
+		job := uipath.Job{
+			ID:                                 s.nextID(uipath.JobEndpoint),
+			Key:                                uuid.NewString(),
+			State:                              uipath.JobStatePending,
+			JobPriority:                        jobPriority,
+			Source:                             source,
+			SourceType:                         source,
+			BatchExecutionKey:                  batch,
+			CreationTime:                       s.timestamp(),
+			ReleaseName:                        release.Name,
+			Type:                               "Unattended",
+			InputArguments:                     info.InputArguments,
+			Reference:                          info.Reference,
+			OrganizationUnitID:                 release.OrganizationUnitID,
+			OrganizationUnitFullyQualifiedName: release.OrganizationUnitFullyQualifiedName,
+		}
+
+		s.jobs = append(s.jobs, job)
+		jobs = append(jobs, job)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"@odata.context": "$metadata#" + uipath.JobEndpoint,
+		"value":          jobs,
+	})
+
+	return nil
+}
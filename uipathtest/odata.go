@@ -0,0 +1,774 @@
+package uipathtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxTop is the largest $top the orchestrator accepts
+const maxTop = 1000
+
+// query holds the odata query options of a list request
+type query struct {
+	filter  node
+	orderBy []orderClause
+	top     int
+	skip    int
+}
+
+type orderClause struct {
+	path       []string
+	descending bool
+}
+
+// node is a parsed $filter expression
+type node interface {
+	eval(row map[string]interface{}) (interface{}, error)
+}
+
+type fieldNode struct {
+	path []string
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+type notNode struct {
+	operand node
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+type token struct {
+	kind  string
+	text  string
+	value interface{}
+}
+
+// parser reads a $filter expression, fields restricts the properties it may reference
+type parser struct {
+	tokens []token
+	pos    int
+	fields map[string]bool
+}
+
+// queryError is an invalid odata query, it is answered with a bad request
+type queryError struct {
+	message string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func queryErrorf(format string, args ...interface{}) error {
+	return &queryError{message: fmt.Sprintf(format, args...)}
+}
+
+// parseQuery reads $filter, $orderby, $top, $skip and $count for an entity of type entity
+func parseQuery(values url.Values, entity interface{}) (query, error) {
+	q := query{top: -1}
+	fields := jsonFields(reflect.TypeOf(entity))
+
+	if filter := values.Get("$filter"); filter != "" {
+		tokens, err := tokenize(filter)
+		if err != nil {
+			return q, err
+		}
+
+		p := &parser{tokens: tokens, fields: fields}
+
+		if q.filter, err = p.parseOr(); err != nil {
+			return q, err
+		}
+
+		if p.pos < len(p.tokens) {
+			return q, queryErrorf("syntax error at %q in $filter", p.tokens[p.pos].text)
+		}
+	}
+
+	if orderBy := values.Get("$orderby"); orderBy != "" {
+		for _, clause := range strings.Split(orderBy, ",") {
+			parts := strings.Fields(clause)
+			if len(parts) < 1 || len(parts) > 2 {
+				return q, queryErrorf("invalid $orderby clause %q", clause)
+			}
+
+			order := orderClause{path: strings.Split(parts[0], "/")}
+			if !fields[order.path[0]] {
+				return q, queryErrorf("could not find a property named '%s'", order.path[0])
+			}
+
+			if len(parts) == 2 {
+				switch strings.ToLower(parts[1]) {
+				case "asc":
+				case "desc":
+					order.descending = true
+				default:
+					return q, queryErrorf("invalid $orderby direction %q", parts[1])
+				}
+			}
+
+			q.orderBy = append(q.orderBy, order)
+		}
+	}
+
+	var err error
+
+	if top := values.Get("$top"); top != "" {
+		if q.top, err = strconv.Atoi(top); err != nil || q.top < 0 {
+			return q, queryErrorf("invalid $top %q", top)
+		}
+
+		if q.top > maxTop {
+			return q, queryErrorf("the limit of '%d' for Top query has been exceeded", maxTop)
+		}
+	}
+
+	if skip := values.Get("$skip"); skip != "" {
+		if q.skip, err = strconv.Atoi(skip); err != nil || q.skip < 0 {
+			return q, queryErrorf("invalid $skip %q", skip)
+		}
+	}
+
+	if count := values.Get("$count"); count != "" && count != "true" && count != "false" {
+		return q, queryErrorf("invalid $count %q", count)
+	}
+
+	return q, nil
+}
+
+// apply filters, sorts and pages the rows, it returns the indexes of the rows in the page
+// and the number of rows matching the filter
+func (q query) apply(rows []map[string]interface{}) ([]int, int, error) {
+	var matches []int
+
+	for i, row := range rows {
+		if q.filter != nil {
+			value, err := q.filter.eval(row)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			if ok, _ := value.(bool); !ok {
+				continue
+			}
+		}
+
+		matches = append(matches, i)
+	}
+
+	var sortErr error
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		for _, order := range q.orderBy {
+			c, err := compare(lookup(rows[matches[i]], order.path), lookup(rows[matches[j]], order.path))
+			if err != nil {
+				sortErr = err
+				return false
+			}
+
+			if c == 0 {
+				continue
+			}
+
+			if order.descending {
+				return c > 0
+			}
+
+			return c < 0
+		}
+
+		return false
+	})
+	if sortErr != nil {
+		return nil, 0, sortErr
+	}
+
+	count := len(matches)
+
+	if q.skip >= len(matches) {
+		return []int{}, count, nil
+	}
+
+	matches = matches[q.skip:]
+
+	if q.top >= 0 && q.top < len(matches) {
+		matches = matches[:q.top]
+	}
+
+	return matches, count, nil
+}
+
+// toRow converts an entity to the map the filters are evaluated on
+func toRow(entity interface{}) map[string]interface{} {
+	row := map[string]interface{}{}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return row
+	}
+
+	_ = json.Unmarshal(data, &row)
+
+	return row
+}
+
+// jsonFields returns the json names of the fields of a struct type
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := map[string]bool{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = true
+	}
+
+	return fields
+}
+
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(filter)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, token{kind: string(r), text: string(r)})
+			i++
+		case r == '\'':
+			var value strings.Builder
+
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, queryErrorf("unterminated string literal in $filter")
+				}
+
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						value.WriteRune('\'')
+						i += 2
+						continue
+					}
+
+					i++
+					break
+				}
+
+				value.WriteRune(runes[i])
+				i++
+			}
+
+			tokens = append(tokens, token{kind: "literal", text: "'" + value.String() + "'", value: value.String()})
+		case unicode.IsDigit(r) || r == '-':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || strings.ContainsRune("-+:.", runes[i])) {
+				i++
+			}
+
+			text := string(runes[start:i])
+
+			if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+				tokens = append(tokens, token{kind: "literal", text: text, value: t})
+				continue
+			}
+
+			number, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, queryErrorf("invalid literal %q in $filter", text)
+			}
+
+			tokens = append(tokens, token{kind: "literal", text: text, value: number})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '/') {
+				i++
+			}
+
+			text := string(runes[start:i])
+
+			switch text {
+			case "true", "false":
+				tokens = append(tokens, token{kind: "literal", text: text, value: text == "true"})
+			case "null":
+				tokens = append(tokens, token{kind: "literal", text: text})
+			default:
+				tokens = append(tokens, token{kind: "ident", text: text})
+			}
+		default:
+			return nil, queryErrorf("unexpected character %q in $filter", r)
+		}
+	}
+
+	return tokens, nil
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+// keyword consumes the next token when it is the identifier word
+func (p *parser) keyword(word string) bool {
+	t, ok := p.peek()
+	if ok && t.kind == "ident" && t.text == word {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(kind string) error {
+	t, ok := p.peek()
+	if !ok {
+		return queryErrorf("unexpected end of $filter, expected %q", kind)
+	}
+
+	if t.kind != kind {
+		return queryErrorf("syntax error at %q in $filter, expected %q", t.text, kind)
+	}
+
+	p.pos++
+
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &binaryNode{op: "or", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &binaryNode{op: "and", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.keyword("not") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := p.peek()
+	if !ok || t.kind != "ident" {
+		return left, nil
+	}
+
+	switch t.text {
+	case "eq", "ne", "gt", "ge", "lt", "le":
+		p.pos++
+	default:
+		return left, nil
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return &binaryNode{op: t.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, queryErrorf("unexpected end of $filter")
+	}
+
+	p.pos++
+
+	switch t.kind {
+	case "literal":
+		return &literalNode{value: t.value}, nil
+	case "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return inner, p.expect(")")
+	case "ident":
+		if next, ok := p.peek(); ok && next.kind == "(" {
+			return p.parseCall(t.text)
+		}
+
+		path := strings.Split(t.text, "/")
+		if !p.fields[path[0]] {
+			return nil, queryErrorf("could not find a property named '%s'", path[0])
+		}
+
+		return &fieldNode{path: path}, nil
+	}
+
+	return nil, queryErrorf("syntax error at %q in $filter", t.text)
+}
+
+func (p *parser) parseCall(name string) (node, error) {
+	arity := map[string]int{
+		"contains":    2,
+		"startswith":  2,
+		"endswith":    2,
+		"substringof": 2,
+		"tolower":     1,
+		"toupper":     1,
+	}
+
+	if _, ok := arity[name]; !ok {
+		return nil, queryErrorf("unknown function '%s'", name)
+	}
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	call := &callNode{name: name}
+
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		call.args = append(call.args, arg)
+
+		if t, ok := p.peek(); ok && t.kind == "," {
+			p.pos++
+			continue
+		}
+
+		break
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if len(call.args) != arity[name] {
+		return nil, queryErrorf("function '%s' takes %d arguments", name, arity[name])
+	}
+
+	return call, nil
+}
+
+func (n *fieldNode) eval(row map[string]interface{}) (interface{}, error) {
+	return lookup(row, n.path), nil
+}
+
+func (n *literalNode) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n *notNode) eval(row map[string]interface{}) (interface{}, error) {
+	value, err := n.operand.eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return nil, queryErrorf("not requires a boolean operand")
+	}
+
+	return !b, nil
+}
+
+func (n *binaryNode) eval(row map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "and" || n.op == "or" {
+		l, lok := left.(bool)
+		r, rok := right.(bool)
+		if !lok || !rok {
+			return nil, queryErrorf("%s requires boolean operands", n.op)
+		}
+
+		if n.op == "and" {
+			return l && r, nil
+		}
+
+		return l || r, nil
+	}
+
+	left, right = zeroValues(left, right)
+
+	if left == nil || right == nil {
+		switch n.op {
+		case "eq":
+			return left == nil && right == nil, nil
+		case "ne":
+			return left != nil || right != nil, nil
+		}
+
+		return false, nil
+	}
+
+	c, err := compare(left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "eq":
+		return c == 0, nil
+	case "ne":
+		return c != 0, nil
+	case "gt":
+		return c > 0, nil
+	case "ge":
+		return c >= 0, nil
+	case "lt":
+		return c < 0, nil
+	}
+
+	return c <= 0, nil
+}
+
+func (n *callNode) eval(row map[string]interface{}) (interface{}, error) {
+	var args []string
+
+	for _, arg := range n.args {
+		value, err := arg.eval(row)
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := value.(type) {
+		case nil:
+			args = append(args, "")
+		case string:
+			args = append(args, v)
+		default:
+			return nil, queryErrorf("function '%s' requires string arguments", n.name)
+		}
+	}
+
+	switch n.name {
+	case "tolower":
+		return strings.ToLower(args[0]), nil
+	case "toupper":
+		return strings.ToUpper(args[0]), nil
+	case "substringof":
+		return strings.Contains(strings.ToLower(args[1]), strings.ToLower(args[0])), nil
+	}
+
+	value, part := strings.ToLower(args[0]), strings.ToLower(args[1])
+
+	switch n.name {
+	case "contains":
+		return strings.Contains(value, part), nil
+	case "startswith":
+		return strings.HasPrefix(value, part), nil
+	}
+
+	return strings.HasSuffix(value, part), nil
+}
+
+// lookup reads a property of the row, following the / separated path into complex properties
+func lookup(row map[string]interface{}, path []string) interface{} {
+	var value interface{} = row
+
+	for _, name := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		value = object[name]
+	}
+
+	return value
+}
+
+// zeroValues replaces a missing property compared to a number, a boolean or a string with its zero
+// value, the entities omit their zero values when encoded
+func zeroValues(left, right interface{}) (interface{}, interface{}) {
+	zero := func(other interface{}) interface{} {
+		switch other.(type) {
+		case float64:
+			return float64(0)
+		case bool:
+			return false
+		case string:
+			return ""
+		}
+
+		return nil
+	}
+
+	if left == nil {
+		left = zero(right)
+	}
+
+	if right == nil {
+		right = zero(left)
+	}
+
+	return left, right
+}
+
+// compare orders two values, strings are compared without case like the orchestrator database does
+// and strings are read as dates when compared to a date
+func compare(left, right interface{}) (int, error) {
+	if left == nil || right == nil {
+		switch {
+		case left == nil && right == nil:
+			return 0, nil
+		case left == nil:
+			return -1, nil
+		}
+
+		return 1, nil
+	}
+
+	if t, ok := right.(time.Time); ok {
+		l, err := asTime(left)
+		if err != nil {
+			return 0, err
+		}
+
+		return compareTimes(l, t), nil
+	}
+
+	if t, ok := left.(time.Time); ok {
+		r, err := asTime(right)
+		if err != nil {
+			return 0, err
+		}
+
+		return compareTimes(t, r), nil
+	}
+
+	switch l := left.(type) {
+	case float64:
+		if r, ok := right.(float64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+
+			return 0, nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(strings.ToLower(l), strings.ToLower(r)), nil
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			switch {
+			case l == r:
+				return 0, nil
+			case !l:
+				return -1, nil
+			}
+
+			return 1, nil
+		}
+	}
+
+	return 0, queryErrorf("cannot compare %v with %v", left, right)
+}
+
+func asTime(value interface{}) (time.Time, error) {
+	if s, ok := value.(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, queryErrorf("cannot compare %v with a date", value)
+}
+
+func compareTimes(left, right time.Time) int {
+	switch {
+	case left.Before(right):
+		return -1
+	case left.After(right):
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,76 @@
+package uipathtest
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryFilter(t *testing.T) {
+	rows := []map[string]interface{}{
+		toRow(uipath.QueueItem{ID: 1, Reference: "INV-1", Status: "New", CreationTime: "2024-01-01T10:00:00.000Z"}),
+		toRow(uipath.QueueItem{ID: 2, Reference: "INV-2", Status: "Failed", CreationTime: "2024-01-02T10:00:00.000Z",
+			ProcessingException: &uipath.ProcessingException{Reason: "It's broken"}}),
+		toRow(uipath.QueueItem{ID: 3, Reference: "ORD-3", Status: "Successful", RetryNumber: 1}),
+	}
+
+	tests := []struct {
+		filter string
+		ids    []uint
+	}{
+		{"Status eq 'new'", []uint{1}},
+		{"Status ne 'New'", []uint{2, 3}},
+		{"Id gt 1 and Id lt 3", []uint{2}},
+		{"Id ge 2 or Id le 1", []uint{1, 2, 3}},
+		{"not (Status eq 'New')", []uint{2, 3}},
+		{"CreationTime ge 2024-01-02T00:00:00Z", []uint{2}},
+		{"CreationTime eq null", []uint{3}},
+		{"RetryNumber eq 0", []uint{1, 2}},
+		{"startswith(Reference,'INV') and contains(ProcessingException/Reason, 'it''s')", []uint{2}},
+		{"endswith(tolower(Reference), 'ord-3')", []uint{3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			q, err := parseQuery(url.Values{"$filter": {test.filter}}, uipath.QueueItem{})
+			require.NoError(t, err)
+
+			indexes, count, err := q.apply(rows)
+			require.NoError(t, err)
+
+			var ids []uint
+			for _, i := range indexes {
+				ids = append(ids, uint(rows[i]["Id"].(float64)))
+			}
+
+			assert.Equal(t, test.ids, ids)
+			assert.Equal(t, len(test.ids), count)
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []url.Values{
+		{"$filter": {"Unknown eq 1"}},
+		{"$filter": {"Status eq 'New"}},
+		{"$filter": {"(Status eq 'New'"}},
+		{"$filter": {"length(Reference) eq 3"}},
+		{"$orderby": {"Id sideways"}},
+		{"$top": {"1001"}},
+		{"$skip": {"-1"}},
+	}
+
+	for _, values := range tests {
+		_, err := parseQuery(values, uipath.QueueItem{})
+		assert.Error(t, err, values.Encode())
+	}
+
+	q, err := parseQuery(url.Values{"$filter": {"Id eq 'one'"}}, uipath.QueueItem{})
+	require.NoError(t, err)
+
+	_, _, err = q.apply([]map[string]interface{}{toRow(uipath.QueueItem{ID: 1})})
+	assert.Error(t, err)
+}
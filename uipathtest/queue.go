@@ -0,0 +1,267 @@
+package uipathtest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/google/uuid"
+)
+
+const (
+	queueAddItemAction      = "UiPathODataSvc.AddQueueItem"
+	queueBulkAddItemsAction = "UiPathODataSvc.BulkAddQueueItems"
+	queueStartAction        = "UiPathODataSvc.StartTransaction"
+)
+
+// priorityRanks orders the items started by a transaction
+var priorityRanks = map[string]int{
+	uipath.PriorityHigh:   0,
+	uipath.PriorityNormal: 1,
+	uipath.PriorityLow:    2,
+}
+
+// AddQueue creates a queue in a folder, its id and key are set when empty
+func (s *Server) AddQueue(folderID uint, queue uipath.QueueDefinition) uipath.QueueDefinition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if queue.ID == 0 {
+		queue.ID = s.nextID(uipath.QueueDefinitionEndpoint)
+	}
+
+	if queue.Key == "" {
+		queue.Key = uuid.NewString()
+	}
+
+	if queue.CreationTime == "" {
+		queue.CreationTime = s.timestamp()
+	}
+
+	queue.OrganizationUnitID = folderID
+	if i := s.folderIndex(folderID); i >= 0 {
+		queue.OrganizationUnitFullyQualifiedName = s.folders[i].FullyQualifiedName
+	}
+
+	s.queues = append(s.queues, queue)
+
+	return queue
+}
+
+func (s *Server) queueByID(ID uint) (uipath.QueueDefinition, bool) {
+	for _, queue := range s.queues {
+		if queue.ID == ID {
+			return queue, true
+		}
+	}
+
+	return uipath.QueueDefinition{}, false
+}
+
+func (s *Server) queueByName(folderID uint, name string) (uipath.QueueDefinition, bool) {
+	for _, queue := range s.queues {
+		if queue.OrganizationUnitID == folderID && strings.EqualFold(queue.Name, name) {
+			return queue, true
+		}
+	}
+
+	return uipath.QueueDefinition{}, false
+}
+
+func (s *Server) serveQueueDefinitions(w http.ResponseWriter, r *http.Request, req route) *apiError {
+	if req.action != "" {
+		return notFoundRoute(r)
+	}
+
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(r)
+	}
+
+	queues := []uipath.QueueDefinition{}
+	for _, queue := range s.queues {
+		if queue.OrganizationUnitID == req.folderID {
+			queues = append(queues, queue)
+		}
+	}
+
+	if !req.hasKey {
+		return list(w, r, uipath.QueueDefinitionEndpoint, queues)
+	}
+
+	for _, queue := range queues {
+		if queue.ID == req.key {
+			writeJSON(w, http.StatusOK, queue)
+			return nil
+		}
+	}
+
+	return notFound("Queue")
+}
+
+// serveQueues answers the queue actions, which add items and start transactions
+func (s *Server) serveQueues(w http.ResponseWriter, r *http.Request, req route, body []byte) *apiError {
+	if req.hasKey {
+		return notFoundRoute(r)
+	}
+
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(r)
+	}
+
+	switch req.action {
+	case queueAddItemAction:
+		return s.addQueueItemAction(w, req, body)
+	case queueBulkAddItemsAction:
+		return s.bulkAddQueueItemsAction(w, req, body)
+	case queueStartAction:
+		return s.startTransactionAction(w, req, body)
+	}
+
+	return notFoundRoute(r)
+}
+
+func (s *Server) addQueueItemAction(w http.ResponseWriter, req route, body []byte) *apiError {
+	var request uipath.QueueItemCreateRequest
+
+	if err := decode(body, &request); err != nil {
+		return err
+	}
+
+	queue, ok := s.queueByName(req.folderID, request.ItemData.Name)
+	if !ok {
+		return notFound("Queue")
+	}
+
+	item, err := s.addItem(queue, request.ItemData)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, item)
+
+	return nil
+}
+
+func (s *Server) bulkAddQueueItemsAction(w http.ResponseWriter, req route, body []byte) *apiError {
+	var request uipath.QueueItemBulkCreateRequest
+
+	if err := decode(body, &request); err != nil {
+		return err
+	}
+
+	queue, ok := s.queueByName(req.folderID, request.QueueName)
+	if !ok {
+		return notFound("Queue")
+	}
+
+	failures := []uipath.QueueItemBulkFailure{}
+	fail := func(item uipath.QueueItem, err *apiError) {
+		failures = append(failures, uipath.QueueItemBulkFailure{ItemData: item, ErrorCode: err.errorCode, ErrorMessage: err.message})
+	}
+
+	switch request.CommitType {
+	case uipath.BulkCommitAllOrNothing:
+		references := map[string]bool{}
+
+		for _, item := range request.QueueItems {
+			if err := s.validateItem(queue, item); err != nil {
+				fail(item, err)
+			} else if queue.EnforceUniqueReference && item.Reference != "" && references[item.Reference] {
+				fail(item, duplicateReference())
+			}
+
+			references[item.Reference] = true
+		}
+
+		if len(failures) > 0 {
+			break
+		}
+
+		for _, item := range request.QueueItems {
+			if _, err := s.addItem(queue, item); err != nil {
+				fail(item, err)
+			}
+		}
+	case uipath.BulkCommitStopOnFirstFailure, uipath.BulkCommitProcessAllIndependently:
+		for _, item := range request.QueueItems {
+			if _, err := s.addItem(queue, item); err != nil {
+				fail(item, err)
+
+				if request.CommitType == uipath.BulkCommitStopOnFirstFailure {
+					break
+				}
+			}
+		}
+	default:
+		return invalid("Invalid commit type: %s.", request.CommitType)
+	}
+
+	writeJSON(w, http.StatusOK, uipath.QueueItemBulkFailureList{Value: failures})
+
+	return nil
+}
+
+// startTransactionAction sets the next item of the queue in progress, by priority then creation
+func (s *Server) startTransactionAction(w http.ResponseWriter, req route, body []byte) *apiError {
+	var request uipath.TransactionStartRequest
+
+	if err := decode(body, &request); err != nil {
+		return err
+	}
+
+	data := request.TransactionData
+
+	queue, ok := s.queueByName(req.folderID, data.Name)
+	if !ok {
+		return notFound("Queue")
+	}
+
+	now := s.now()
+
+	var candidates []int
+	for i, item := range s.items {
+		if item.QueueDefinitionID != queue.ID || item.Status != uipath.QueueItemStatusNew {
+			continue
+		}
+
+		if deferred, err := time.Parse(time.RFC3339Nano, item.DeferDate); err == nil && deferred.After(now) {
+			continue
+		}
+
+		if data.Reference != "" {
+			if data.ReferenceFilterOption == "StartsWith" && !strings.HasPrefix(item.Reference, data.Reference) {
+				continue
+			}
+
+			if data.ReferenceFilterOption != "StartsWith" && item.Reference != data.Reference {
+				continue
+			}
+		}
+
+		candidates = append(candidates, i)
+	}
+
+	if len(candidates) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return priorityRanks[s.items[candidates[i]].Priority] < priorityRanks[s.items[candidates[j]].Priority]
+	})
+
+	item := &s.items[candidates[0]]
+	item.Status = uipath.QueueItemStatusInProgress
+	item.StartProcessing = now.Format(timeFormat)
+	item.RowVersion = s.nextRowVersion()
+
+	writeJSON(w, http.StatusOK, item)
+
+	return nil
+}
+
+func duplicateReference() *apiError {
+	return conflict(uipath.ErrorCodeDuplicateReference, "Error creating Transaction. Duplicate Reference.")
+}
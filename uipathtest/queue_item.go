@@ -0,0 +1,296 @@
+package uipathtest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/google/uuid"
+)
+
+// maxReferenceLength is the longest reference the orchestrator accepts
+const maxReferenceLength = 128
+
+// AddQueueItem creates a queue item in the queue of its QueueDefinitionID, the status defaults to New.
+// It panics when the queue does not exist.
+func (s *Server) AddQueueItem(item uipath.QueueItem) uipath.QueueItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, ok := s.queueByID(item.QueueDefinitionID)
+	if !ok {
+		panic(fmt.Sprintf("uipathtest: queue %d does not exist", item.QueueDefinitionID))
+	}
+
+	status := item.Status
+
+	item = s.newItem(queue, item)
+	if status != "" {
+		item.Status = status
+	}
+
+	s.items = append(s.items, item)
+
+	return item
+}
+
+// QueueItems returns the items of a queue
+func (s *Server) QueueItems(queueDefinitionID uint) []uipath.QueueItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []uipath.QueueItem{}
+	for _, item := range s.items {
+		if item.QueueDefinitionID == queueDefinitionID {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// QueueItem returns a queue item by id
+func (s *Server) QueueItem(ID uint) (uipath.QueueItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.itemIndex(ID); i >= 0 {
+		return s.items[i], true
+	}
+
+	return uipath.QueueItem{}, false
+}
+
+func (s *Server) itemIndex(ID uint) int {
+	for i, item := range s.items {
+		if item.ID == ID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// validateItem checks an item sent to a queue
+func (s *Server) validateItem(queue uipath.QueueDefinition, item uipath.QueueItem) *apiError {
+	if _, ok := priorityRanks[item.Priority]; item.Priority != "" && !ok {
+		return invalid("Invalid priority: %s.", item.Priority)
+	}
+
+	if len(item.Reference) > maxReferenceLength {
+		return invalid("The Reference field must have at most %d characters.", maxReferenceLength)
+	}
+
+	if err := parseDate("DueDate", item.DueDate); err != nil {
+		return err
+	}
+
+	if err := parseDate("DeferDate", item.DeferDate); err != nil {
+		return err
+	}
+
+	if !queue.EnforceUniqueReference {
+		return nil
+	}
+
+	if item.Reference == "" {
+		return invalid("The Reference field is required by queue %s.", queue.Name)
+	}
+
+	for _, existing := range s.items {
+		if existing.QueueDefinitionID == queue.ID && existing.Reference == item.Reference && existing.Status != uipath.QueueItemStatusDeleted {
+			return duplicateReference()
+		}
+	}
+
+	return nil
+}
+
+// addItem validates and stores an item sent to a queue
+func (s *Server) addItem(queue uipath.QueueDefinition, item uipath.QueueItem) (uipath.QueueItem, *apiError) {
+	if err := s.validateItem(queue, item); err != nil {
+		return item, err
+	}
+
+	item = s.newItem(queue, item)
+	s.items = append(s.items, item)
+
+	return item, nil
+}
+
+// newItem fills the fields the orchestrator sets on new queue items
+func (s *Server) newItem(queue uipath.QueueDefinition, item uipath.QueueItem) uipath.QueueItem {
+	now := s.now()
+
+	item.ID = s.nextID(uipath.QueueItemEndpoint)
+	item.Key = uuid.NewString()
+	item.QueueDefinitionID = queue.ID
+	item.Name = ""
+	item.Status = uipath.QueueItemStatusNew
+	item.CreationTime = now.Format(timeFormat)
+	item.RowVersion = s.nextRowVersion()
+	item.OrganizationUnitID = queue.OrganizationUnitID
+	item.OrganizationUnitFullyQualifiedName = queue.OrganizationUnitFullyQualifiedName
+
+	if item.Priority == "" {
+		item.Priority = uipath.PriorityNormal
+	}
+
+	if item.DueDate == "" && queue.SlaInMinutes > 0 {
+		item.DueDate = now.Add(time.Duration(queue.SlaInMinutes) * time.Minute).Format(timeFormat)
+	}
+
+	if item.RiskSlaDate == "" && queue.RiskSlaInMinutes > 0 {
+		item.RiskSlaDate = now.Add(time.Duration(queue.RiskSlaInMinutes) * time.Minute).Format(timeFormat)
+	}
+
+	return item
+}
+
+func (s *Server) serveQueueItems(w http.ResponseWriter, r *http.Request, req route, body []byte) *apiError {
+	items := []uipath.QueueItem{}
+	for _, item := range s.items {
+		if item.OrganizationUnitID == req.folderID {
+			items = append(items, item)
+		}
+	}
+
+	if !req.hasKey {
+		if req.action != "" {
+			return notFoundRoute(r)
+		}
+
+		if r.Method != http.MethodGet {
+			return methodNotAllowed(r)
+		}
+
+		return list(w, r, uipath.QueueItemEndpoint, items)
+	}
+
+	i := s.itemIndex(req.key)
+	if i < 0 || s.items[i].OrganizationUnitID != req.folderID {
+		return notFound("Queue item")
+	}
+
+	switch req.action {
+	case "":
+		if r.Method != http.MethodGet {
+			return methodNotAllowed(r)
+		}
+
+		writeJSON(w, http.StatusOK, s.items[i])
+
+		return nil
+	case uipath.QueueSetTransactionResultEndpoint:
+		if r.Method != http.MethodPost {
+			return methodNotAllowed(r)
+		}
+
+		return s.setTransactionResult(w, i, body)
+	case uipath.QueueSetTransactionProgressEndpoint:
+		if r.Method != http.MethodPost {
+			return methodNotAllowed(r)
+		}
+
+		var request uipath.TransactionProgressRequest
+
+		if err := decode(body, &request); err != nil {
+			return err
+		}
+
+		if err := checkInProgress(s.items[i]); err != nil {
+			return err
+		}
+
+		s.items[i].Progress = request.Progress
+		s.items[i].RowVersion = s.nextRowVersion()
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+
+	return notFoundRoute(r)
+}
+
+// setTransactionResult ends the transaction of an item, failed application exceptions are retried
+// when the queue accepts automatic retries
+func (s *Server) setTransactionResult(w http.ResponseWriter, i int, body []byte) *apiError {
+	var request uipath.TransactionResultRequest
+
+	if err := decode(body, &request); err != nil {
+		return err
+	}
+
+	if err := checkInProgress(s.items[i]); err != nil {
+		return err
+	}
+
+	result := request.TransactionResult
+	now := s.now()
+	item := &s.items[i]
+
+	item.EndProcessing = now.Format(timeFormat)
+	item.Output = result.Output
+	item.RowVersion = s.nextRowVersion()
+
+	if started, err := time.Parse(time.RFC3339Nano, item.StartProcessing); err == nil {
+		item.SecondsInPreviousAttempts += uint(now.Sub(started).Seconds())
+	}
+
+	if result.IsSuccessful {
+		item.Status = uipath.QueueItemStatusSuccessful
+		w.WriteHeader(http.StatusNoContent)
+
+		return nil
+	}
+
+	exception := uipath.ProcessingException{Type: uipath.ExceptionTypeApplication, CreationTime: now}
+	if result.ProcessingException != nil {
+		exception.Reason = result.ProcessingException.Reason
+		exception.Details = result.ProcessingException.Details
+
+		if result.ProcessingException.Type != "" {
+			exception.Type = result.ProcessingException.Type
+		}
+	}
+
+	item.Status = uipath.QueueItemStatusFailed
+	item.ProcessingExceptionType = exception.Type
+	item.ProcessingException = &exception
+
+	queue, _ := s.queueByID(item.QueueDefinitionID)
+	if exception.Type == uipath.ExceptionTypeApplication && queue.AcceptAutomaticallyRetry && int(item.RetryNumber) < queue.MaxNumberOfRetries {
+		item.Status = uipath.QueueItemStatusRetried
+
+		ancestorID := item.ID
+		retry := s.newItem(queue, uipath.QueueItem{
+			Priority:        item.Priority,
+			Reference:       item.Reference,
+			DueDate:         item.DueDate,
+			RiskSlaDate:     item.RiskSlaDate,
+			SpecificContent: item.SpecificContent,
+		})
+		retry.AncestorID = &ancestorID
+		retry.RetryNumber = item.RetryNumber + 1
+		retry.SecondsInPreviousAttempts = item.SecondsInPreviousAttempts
+
+		s.items = append(s.items, retry)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func checkInProgress(item uipath.QueueItem) *apiError {
+	if item.Status != uipath.QueueItemStatusInProgress {
+		return &apiError{
+			statusCode: http.StatusBadRequest,
+			errorCode:  uipath.ErrorCodeInvalidTransactionState,
+			message:    fmt.Sprintf("Queue item %d is %s, not in progress.", item.ID, item.Status),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,521 @@
+// Package uipathtest provides an in-memory fake orchestrator to test code using the uipath client
+// without network access. It serves the identity token endpoint, folders, assets, queues, queue items,
+// transactions, releases and jobs with odata filtering, paging and the orchestrator error codes.
+package uipathtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultApplicationID     = "uipathtest-app"
+	DefaultApplicationSecret = "uipathtest-secret"
+
+	Organization = "uipathtest"
+	Tenant       = "DefaultTenant"
+
+	// TokenPath is the path of the identity token endpoint
+	TokenPath = "/identity_/connect/token"
+
+	// tokenLifetime is the expires_in of the issued tokens, in seconds
+	tokenLifetime = 3600
+
+	timeFormat = "2006-01-02T15:04:05.000Z"
+)
+
+// folderScoped are the entity sets that need the folder header
+var folderScoped = map[string]bool{
+	uipath.AssetEndpoint:           true,
+	uipath.QueueDefinitionEndpoint: true,
+	"Queues":                       true,
+	uipath.QueueItemEndpoint:       true,
+	uipath.ReleaseEndpoint:         true,
+	uipath.JobEndpoint:             true,
+}
+
+// segmentPattern splits the first path segment into the entity set and its key, eg. Assets(12)
+var segmentPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\((\d+)\))?$`)
+
+// Server is a fake orchestrator. Its state is only kept in memory, seed it with the Add methods and
+// inspect it with the getters. It is safe for concurrent use.
+type Server struct {
+	// ApplicationID and ApplicationSecret are the credentials accepted by the token endpoint,
+	// change them before the first request
+	ApplicationID     string
+	ApplicationSecret string
+
+	// DefaultFolder is the folder created with the server
+	DefaultFolder uipath.Folder
+
+	// Now returns the current time, it can be replaced before the first request to control dates
+	Now func() time.Time
+
+	server *httptest.Server
+
+	mu         sync.Mutex
+	tokens     map[string]bool
+	ids        map[string]uint
+	rowVersion uint64
+	folders    []uipath.Folder
+	assets     []storedAsset
+	queues     []uipath.QueueDefinition
+	items      []uipath.QueueItem
+	releases   []uipath.Release
+	jobs       []uipath.Job
+	failures   []Failure
+	requests   []Request
+}
+
+// Failure makes the server answer the matching requests with an error
+type Failure struct {
+	// Method matches any method when empty
+	Method string
+
+	// Path is matched against the beginning of the path relative to the odata root, eg. Assets or
+	// Queues/UiPathODataSvc.AddQueueItem, it matches every request when empty
+	Path string
+
+	StatusCode int
+	ErrorCode  int
+	Message    string
+
+	// Times is the number of requests failed, it defaults to 1
+	Times int
+
+	// AfterHandling handles the request before answering with the error, like a response lost on the way back
+	AfterHandling bool
+}
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+
+	// Path is relative to the odata root, or the full path for the token endpoint
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// route is a parsed odata request
+type route struct {
+	entitySet string
+	key       uint
+	hasKey    bool
+	action    string
+	folderID  uint
+}
+
+// apiError is an error answered by the server
+type apiError struct {
+	statusCode int
+	errorCode  int
+	message    string
+}
+
+// NewServer starts a fake orchestrator with a default Shared folder, close it when done
+func NewServer() *Server {
+	s := &Server{
+		ApplicationID:     DefaultApplicationID,
+		ApplicationSecret: DefaultApplicationSecret,
+		Now:               time.Now,
+		tokens:            map[string]bool{},
+		ids:               map[string]uint{},
+	}
+
+	s.DefaultFolder = s.AddFolder(uipath.Folder{DisplayName: "Shared"})
+	s.server = httptest.NewServer(s)
+
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL is the root url of the server
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// BaseURL is the odata url of the orchestrator, to be used as the client BaseURL
+func (s *Server) BaseURL() string {
+	return fmt.Sprintf("%s/%s/%s/orchestrator_/odata/", s.server.URL, Organization, Tenant)
+}
+
+// TokenURL is the url of the identity token endpoint
+func (s *Server) TokenURL() string {
+	return s.server.URL + TokenPath
+}
+
+// Client creates a client for the server, the options are applied after the ones pointing it to the server
+func (s *Server) Client(opts ...uipath.Option) (*uipath.Client, error) {
+	options := []uipath.Option{
+		uipath.WithHTTPClient(s.server.Client()),
+		uipath.WithBaseURL(s.BaseURL()),
+		uipath.WithTokenURL(s.TokenURL()),
+		uipath.WithApplicationCredentials(s.ApplicationID, s.ApplicationSecret, "OR.Default"),
+	}
+
+	return uipath.NewClient(append(options, opts...)...)
+}
+
+// Fail registers a failure for the next matching requests
+func (s *Server) Fail(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if failure.Times <= 0 {
+		failure.Times = 1
+	}
+
+	if failure.StatusCode == 0 {
+		failure.StatusCode = http.StatusInternalServerError
+	}
+
+	s.failures = append(s.failures, failure)
+}
+
+// RevokeTokens invalidates the issued tokens, the next requests using them are unauthorized
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]bool{}
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+// ServeHTTP answers a request to the orchestrator
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, &apiError{statusCode: http.StatusBadRequest, errorCode: uipath.ErrorCodeInvalidRequest, message: err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == TokenPath {
+		s.record(r, r.URL.Path, body)
+		s.serveToken(w, r, body)
+		return
+	}
+
+	index := strings.Index(r.URL.Path, "/odata/")
+	if index < 0 {
+		s.record(r, r.URL.Path, body)
+		writeError(w, &apiError{statusCode: http.StatusNotFound, message: fmt.Sprintf("No HTTP resource was found that matches the request URI '%s'.", r.URL.Path)})
+		return
+	}
+
+	path := r.URL.Path[index+len("/odata/"):]
+	s.record(r, path, body)
+
+	if !s.tokens[strings.TrimPrefix(r.Header.Get(uipath.HeaderAuthorization), "Bearer ")] {
+		writeError(w, &apiError{statusCode: http.StatusUnauthorized, message: "You are not authenticated!"})
+		return
+	}
+
+	failure, failed := s.failure(r.Method, path)
+	if failed && !failure.AfterHandling {
+		writeError(w, &apiError{statusCode: failure.StatusCode, errorCode: failure.ErrorCode, message: failure.Message})
+		return
+	}
+
+	// The request is handled but its response is replaced by the failure
+	response := w
+	if failed {
+		response = httptest.NewRecorder()
+	}
+
+	req, apiErr := s.route(r, path)
+	if apiErr == nil {
+		apiErr = s.serve(response, r, req, body)
+	}
+
+	if apiErr != nil {
+		writeError(response, apiErr)
+	}
+
+	if failed {
+		writeError(w, &apiError{statusCode: failure.StatusCode, errorCode: failure.ErrorCode, message: failure.Message})
+	}
+}
+
+// serve dispatches a request to the handler of its entity set
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, req route, body []byte) *apiError {
+	switch req.entitySet {
+	case "Folders":
+		return s.serveFolders(w, r, req, body)
+	case uipath.AssetEndpoint:
+		return s.serveAssets(w, r, req, body)
+	case uipath.QueueDefinitionEndpoint:
+		return s.serveQueueDefinitions(w, r, req)
+	case "Queues":
+		return s.serveQueues(w, r, req, body)
+	case uipath.QueueItemEndpoint:
+		return s.serveQueueItems(w, r, req, body)
+	case uipath.ReleaseEndpoint:
+		return s.serveReleases(w, r, req)
+	case uipath.JobEndpoint:
+		return s.serveJobs(w, r, req, body)
+	}
+
+	return notFoundRoute(r)
+}
+
+// serveToken answers the client credentials grant
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request, body []byte) {
+	form, err := url.ParseQuery(string(body))
+	if r.Method != http.MethodPost || err != nil || form.Get("grant_type") != "client_credentials" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	if form.Get("client_id") != s.ApplicationID || form.Get("client_secret") != s.ApplicationSecret {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	token := "uipathtest-" + uuid.NewString()
+	s.tokens[token] = true
+
+	writeJSON(w, http.StatusOK, uipath.OauthTokenResponse{
+		AccessToken: token,
+		Scope:       form.Get("scope"),
+		ExpiresIn:   tokenLifetime,
+		TokenType:   "Bearer",
+	})
+}
+
+// route parses the path of an odata request and checks its folder
+func (s *Server) route(r *http.Request, path string) (route, *apiError) {
+	var req route
+
+	segment, action, _ := strings.Cut(path, "/")
+
+	match := segmentPattern.FindStringSubmatch(segment)
+	if match == nil {
+		return req, notFoundRoute(r)
+	}
+
+	req.entitySet = match[1]
+	req.action = action
+
+	if match[2] != "" {
+		key, err := strconv.ParseUint(match[2], 10, 64)
+		if err != nil {
+			return req, notFoundRoute(r)
+		}
+
+		req.key, req.hasKey = uint(key), true
+	}
+
+	if !folderScoped[req.entitySet] {
+		return req, nil
+	}
+
+	header := r.Header.Get(uipath.HeaderOrganizationUnitId)
+	if header == "" || header == "0" {
+		return req, &apiError{statusCode: http.StatusBadRequest, errorCode: uipath.ErrorCodeFolderRequired, message: "A folder is required for this action."}
+	}
+
+	folderID, err := strconv.ParseUint(header, 10, 64)
+	if err != nil {
+		return req, &apiError{statusCode: http.StatusBadRequest, errorCode: uipath.ErrorCodeInvalidRequest, message: fmt.Sprintf("Invalid %s header.", uipath.HeaderOrganizationUnitId)}
+	}
+
+	if s.folderIndex(uint(folderID)) < 0 {
+		return req, &apiError{statusCode: http.StatusNotFound, errorCode: uipath.ErrorCodeFolderNotFound, message: "Folder does not exist or the user does not have access to the folder."}
+	}
+
+	req.folderID = uint(folderID)
+
+	return req, nil
+}
+
+// failure returns the first registered failure matching the request and counts it
+func (s *Server) failure(method string, path string) (Failure, bool) {
+	for i, failure := range s.failures {
+		if failure.Method != "" && !strings.EqualFold(failure.Method, method) {
+			continue
+		}
+
+		if !strings.HasPrefix(path, failure.Path) {
+			continue
+		}
+
+		s.failures[i].Times--
+		if s.failures[i].Times <= 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+
+		return failure, true
+	}
+
+	return Failure{}, false
+}
+
+func (s *Server) record(r *http.Request, path string, body []byte) {
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+}
+
+// nextID returns the next id of an entity set
+func (s *Server) nextID(entitySet string) uint {
+	s.ids[entitySet]++
+
+	return s.ids[entitySet]
+}
+
+// nextRowVersion returns a new row version, encoded like the orchestrator timestamps
+func (s *Server) nextRowVersion() string {
+	s.rowVersion++
+
+	version := make([]byte, 8)
+	binary.BigEndian.PutUint64(version, s.rowVersion)
+
+	return base64.StdEncoding.EncodeToString(version)
+}
+
+func (s *Server) now() time.Time {
+	return s.Now().UTC()
+}
+
+func (s *Server) timestamp() string {
+	return s.now().Format(timeFormat)
+}
+
+// list answers a list request with the page of the entities matching its query
+func list(w http.ResponseWriter, r *http.Request, entitySet string, entities interface{}) *apiError {
+	values := reflect.ValueOf(entities)
+
+	q, err := parseQuery(r.URL.Query(), reflect.Zero(values.Type().Elem()).Interface())
+	if err != nil {
+		return badQuery(err)
+	}
+
+	rows := make([]map[string]interface{}, values.Len())
+	for i := range rows {
+		rows[i] = toRow(values.Index(i).Interface())
+	}
+
+	indexes, count, err := q.apply(rows)
+	if err != nil {
+		return badQuery(err)
+	}
+
+	page := reflect.MakeSlice(values.Type(), 0, len(indexes))
+	for _, i := range indexes {
+		page = reflect.Append(page, values.Index(i))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"@odata.context": "$metadata#" + entitySet,
+		"@odata.count":   count,
+		"value":          page.Interface(),
+	})
+
+	return nil
+}
+
+// decode reads a json request body
+func decode(body []byte, v interface{}) *apiError {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return &apiError{statusCode: http.StatusBadRequest, errorCode: uipath.ErrorCodeInvalidRequest, message: "The request body is invalid: " + err.Error()}
+	}
+
+	return nil
+}
+
+// parseDate checks an optional date of a request
+func parseDate(name string, value string) *apiError {
+	if value == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+		return invalid("%s is not a valid date.", name)
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; odata.metadata=minimal")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.statusCode, uipath.RequestError{
+		Message:   err.message,
+		ErrorCode: err.errorCode,
+		TraceIDs:  uuid.NewString(),
+	})
+}
+
+func badQuery(err error) *apiError {
+	var queryErr *queryError
+	if errors.As(err, &queryErr) {
+		return invalid("The query specified in the URI is not valid. %s", queryErr.message)
+	}
+
+	return &apiError{statusCode: http.StatusInternalServerError, message: err.Error()}
+}
+
+func invalid(format string, args ...interface{}) *apiError {
+	return &apiError{statusCode: http.StatusBadRequest, errorCode: uipath.ErrorCodeInvalidRequest, message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(entity string) *apiError {
+	return &apiError{statusCode: http.StatusNotFound, errorCode: uipath.ErrorCodeItemNotFound, message: entity + " does not exist."}
+}
+
+func conflict(errorCode int, message string) *apiError {
+	return &apiError{statusCode: http.StatusConflict, errorCode: errorCode, message: message}
+}
+
+func notFoundRoute(r *http.Request) *apiError {
+	return &apiError{statusCode: http.StatusNotFound, message: fmt.Sprintf("No HTTP resource was found that matches the request URI '%s'.", r.URL.Path)}
+}
+
+func methodNotAllowed(r *http.Request) *apiError {
+	return &apiError{statusCode: http.StatusMethodNotAllowed, message: fmt.Sprintf("The requested resource does not support http method '%s'.", r.Method)}
+}
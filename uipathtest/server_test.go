@@ -0,0 +1,340 @@
+package uipathtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/comvex-jp/uipath-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	s *Server
+	c *uipath.Client
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	suite.s = NewServer()
+
+	c, err := suite.s.Client()
+	suite.Require().NoError(err)
+
+	suite.c = c
+}
+
+func (suite *ServerTestSuite) TearDownTest() {
+	suite.s.Close()
+}
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (suite *ServerTestSuite) TestTokenRejectsWrongCredentials() {
+	resp, err := http.PostForm(suite.s.TokenURL(), url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {DefaultApplicationID},
+		"client_secret": {"wrong"},
+	})
+	suite.Require().NoError(err)
+	resp.Body.Close()
+
+	assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+}
+
+func (suite *ServerTestSuite) TestUnauthorizedWithRevokedToken() {
+	handler := uipath.AssetHandler{Client: suite.c, FolderId: suite.s.DefaultFolder.ID}
+
+	_, _, err := handler.List(nil)
+	suite.Require().NoError(err)
+
+	suite.s.RevokeTokens()
+
+	_, _, err = handler.List(nil)
+	assert.ErrorIs(suite.T(), err, uipath.ErrUnauthorized)
+}
+
+func (suite *ServerTestSuite) TestAssets() {
+	folder := suite.s.DefaultFolder.ID
+	handler := uipath.AssetHandler{Client: suite.c, FolderId: folder}
+
+	stored, err := handler.Store(uipath.NewCredentialAsset("Login", "robot", "p4ss"))
+	suite.Require().NoError(err)
+	assert.NotZero(suite.T(), stored.ID)
+	assert.Empty(suite.T(), stored.CredentialPassword)
+
+	_, err = handler.Store(uipath.NewTextAsset("login", "x"))
+	assert.ErrorIs(suite.T(), err, uipath.ErrConflict)
+
+	_, err = handler.Upsert(uipath.NewIntegerAsset("Retries", 0))
+	suite.Require().NoError(err)
+
+	updated, err := handler.Upsert(uipath.NewIntegerAsset("Retries", 3))
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 3, updated.IntValue)
+
+	found, err := handler.GetByName("Login")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), stored.ID, found.ID)
+	assert.Equal(suite.T(), "p4ss", suite.s.Assets(folder)[0].CredentialPassword)
+
+	suite.Require().NoError(handler.DeleteByID(stored.ID))
+
+	_, err = handler.GetByID(stored.ID)
+	assert.ErrorIs(suite.T(), err, uipath.ErrNotFound)
+
+	assets, count, err := handler.List(nil)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, count)
+	assert.Equal(suite.T(), "Retries", assets[0].Name)
+}
+
+func (suite *ServerTestSuite) TestFolderErrors() {
+	_, _, err := (&uipath.AssetHandler{Client: suite.c}).List(nil)
+
+	var apiError *uipath.APIError
+	suite.Require().True(errors.As(err, &apiError))
+	assert.Equal(suite.T(), uipath.ErrorCodeFolderRequired, apiError.ErrorCode)
+
+	_, _, err = (&uipath.AssetHandler{Client: suite.c, FolderId: 99}).List(nil)
+	assert.ErrorIs(suite.T(), err, uipath.ErrNotFound)
+}
+
+func (suite *ServerTestSuite) TestListFiltersAndPages() {
+	folder := suite.s.DefaultFolder.ID
+	queue := suite.s.AddQueue(folder, uipath.QueueDefinition{Name: "Invoices"})
+
+	for i := 0; i < 5; i++ {
+		status := uipath.QueueItemStatusSuccessful
+		if i%2 == 0 {
+			status = uipath.QueueItemStatusFailed
+		}
+
+		suite.s.AddQueueItem(uipath.QueueItem{QueueDefinitionID: queue.ID, Reference: "INV-" + string(rune('A'+i)), Status: status})
+	}
+
+	handler := uipath.QueueItemHandler{Client: suite.c, FolderId: folder}
+
+	items, count, err := handler.List(map[string]string{
+		"$filter":  "Status eq 'failed' and (Reference eq 'INV-A' or Reference ne 'INV-C')",
+		"$orderby": "Id desc",
+		"$top":     "1",
+		"$skip":    "1",
+		"$count":   "true",
+	})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2, count)
+	suite.Require().Len(items, 1)
+	assert.Equal(suite.T(), "INV-A", items[0].Reference)
+
+	_, _, err = handler.List(map[string]string{"$filter": "Unknown eq 1"})
+	assert.ErrorIs(suite.T(), err, uipath.ErrorCategoryValidation)
+
+	exported := 0
+	err = (&uipath.QueueExporter{Handler: &handler, Filter: uipath.QueueItemFilter{QueueDefinitionID: queue.ID}}).Each(func(item uipath.QueueItem) error {
+		exported++
+		return nil
+	})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 5, exported)
+}
+
+func (suite *ServerTestSuite) TestStoreIdempotentWithLostResponse() {
+	folder := suite.s.DefaultFolder.ID
+	queue := suite.s.AddQueue(folder, uipath.QueueDefinition{Name: "Orders", EnforceUniqueReference: true})
+	handler := uipath.QueueItemHandler{Client: suite.c, FolderId: folder}
+
+	suite.s.Fail(Failure{Method: "POST", Path: uipath.QueueAddItemEndpoint, StatusCode: http.StatusServiceUnavailable, AfterHandling: true})
+
+	item, err := handler.StoreIdempotent(uipath.QueueItem{Name: "Orders", Reference: "ORD-1"})
+	suite.Require().NoError(err)
+	assert.NotZero(suite.T(), item.ID)
+	assert.Len(suite.T(), suite.s.QueueItems(queue.ID), 1)
+
+	_, err = handler.Store(uipath.QueueItem{Name: "Orders", Reference: "ORD-1"})
+	assert.ErrorIs(suite.T(), err, uipath.ErrDuplicateReference)
+}
+
+func (suite *ServerTestSuite) TestStoreIdempotentWithLostResponseAndFailedLookup() {
+	folder := suite.s.DefaultFolder.ID
+	queue := suite.s.AddQueue(folder, uipath.QueueDefinition{Name: "Orders"})
+	handler := uipath.QueueItemHandler{Client: suite.c, FolderId: folder}
+
+	suite.s.Fail(Failure{Method: "POST", Path: uipath.QueueAddItemEndpoint, StatusCode: http.StatusServiceUnavailable, AfterHandling: true})
+	suite.s.Fail(Failure{Method: "GET", Path: uipath.QueueItemEndpoint, StatusCode: http.StatusInternalServerError, Times: 10})
+
+	_, err := handler.StoreIdempotent(uipath.QueueItem{Name: "Orders", Reference: "ORD-1"})
+
+	var apiError *uipath.APIError
+	suite.Require().ErrorAs(err, &apiError)
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, apiError.StatusCode)
+	assert.Len(suite.T(), suite.s.QueueItems(queue.ID), 1)
+}
+
+func (suite *ServerTestSuite) TestBulkStore() {
+	folder := suite.s.DefaultFolder.ID
+	queue := suite.s.AddQueue(folder, uipath.QueueDefinition{Name: "Orders", EnforceUniqueReference: true})
+	handler := uipath.QueueItemHandler{Client: suite.c, FolderId: folder}
+
+	items := []uipath.QueueItem{{Reference: "A"}, {Reference: "A"}, {Reference: "B", Priority: "Urgent"}}
+
+	failures, err := handler.BulkStore("Orders", items, uipath.BulkCommitAllOrNothing)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), failures, 2)
+	assert.Empty(suite.T(), suite.s.QueueItems(queue.ID))
+
+	failures, err = handler.BulkStore("Orders", items, "")
+	suite.Require().NoError(err)
+	suite.Require().Len(failures, 2)
+	assert.Equal(suite.T(), uipath.ErrorCodeDuplicateReference, failures[0].ErrorCode)
+	assert.Len(suite.T(), suite.s.QueueItems(queue.ID), 1)
+}
+
+func (suite *ServerTestSuite) TestTransactions() {
+	folder := suite.s.DefaultFolder.ID
+	queue := suite.s.AddQueue(folder, uipath.QueueDefinition{Name: "Orders", AcceptAutomaticallyRetry: true, MaxNumberOfRetries: 1})
+	low := suite.s.AddQueueItem(uipath.QueueItem{QueueDefinitionID: queue.ID, Priority: uipath.PriorityLow})
+	high := suite.s.AddQueueItem(uipath.QueueItem{QueueDefinitionID: queue.ID, Priority: uipath.PriorityHigh})
+
+	handler := uipath.QueueItemHandler{Client: suite.c, FolderId: folder}
+
+	started, err := handler.StartTransaction(uipath.TransactionData{Name: "Orders"})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), high.ID, started.ID)
+	assert.Equal(suite.T(), uipath.QueueItemStatusInProgress, started.Status)
+
+	suite.Require().NoError(handler.SetTransactionProgress(started.ID, "half way"))
+	suite.Require().NoError(handler.SetTransactionResult(started.ID, uipath.TransactionResult{
+		ProcessingException: &uipath.TransactionException{Reason: "timeout", Type: uipath.ExceptionTypeApplication},
+	}))
+
+	err = handler.SetTransactionResult(started.ID, uipath.TransactionResult{IsSuccessful: true})
+	assert.ErrorIs(suite.T(), err, uipath.ErrConflict)
+
+	items := suite.s.QueueItems(queue.ID)
+	suite.Require().Len(items, 3)
+	assert.Equal(suite.T(), uipath.QueueItemStatusRetried, items[1].Status)
+	assert.Equal(suite.T(), high.ID, *items[2].AncestorID)
+
+	started, err = handler.StartTransaction(uipath.TransactionData{Name: "Orders"})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), items[2].ID, started.ID)
+
+	suite.Require().NoError(handler.SetTransactionResult(started.ID, uipath.TransactionResult{
+		ProcessingException: &uipath.TransactionException{Reason: "timeout", Type: uipath.ExceptionTypeApplication},
+	}))
+
+	started, err = handler.StartTransaction(uipath.TransactionData{Name: "Orders"})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), low.ID, started.ID)
+
+	suite.Require().NoError(handler.SetTransactionResult(started.ID, uipath.TransactionResult{IsSuccessful: true}))
+
+	started, err = handler.StartTransaction(uipath.TransactionData{Name: "Orders"})
+	suite.Require().NoError(err)
+	assert.Zero(suite.T(), started.ID)
+
+	item, _ := suite.s.QueueItem(items[2].ID)
+	assert.Equal(suite.T(), uipath.QueueItemStatusFailed, item.Status)
+}
+
+func (suite *ServerTestSuite) TestWorker() {
+	folder := suite.s.DefaultFolder.ID
+	queue := suite.s.AddQueue(folder, uipath.QueueDefinition{Name: "Orders"})
+
+	for i := 0; i < 3; i++ {
+		suite.s.AddQueueItem(uipath.QueueItem{QueueDefinitionID: queue.ID, SpecificContent: map[string]interface{}{"Amount": i}})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	processed := make(chan struct{}, 3)
+	worker := uipath.Worker{
+		Handler:    &uipath.QueueItemHandler{Client: suite.c, FolderId: folder},
+		Queue:      "Orders",
+		MinBackoff: 10 * time.Millisecond,
+		Process: func(ctx context.Context, item uipath.QueueItem) (map[string]interface{}, error) {
+			defer func() { processed <- struct{}{} }()
+
+			if item.SpecificContent["Amount"] == float64(1) {
+				return nil, uipath.NewBusinessError("invalid amount")
+			}
+
+			return map[string]interface{}{"Done": true}, nil
+		},
+	}
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			<-processed
+		}
+
+		cancel()
+	}()
+
+	_ = worker.Run(ctx)
+
+	statuses := map[string]int{}
+	for _, item := range suite.s.QueueItems(queue.ID) {
+		statuses[item.Status]++
+	}
+
+	assert.Equal(suite.T(), map[string]int{uipath.QueueItemStatusSuccessful: 2, uipath.QueueItemStatusFailed: 1}, statuses)
+}
+
+func (suite *ServerTestSuite) TestJobs() {
+	folder := suite.s.AddFolder(uipath.Folder{DisplayName: "Finance"})
+	release := suite.s.AddRelease(folder.ID, uipath.Release{Name: "Billing"})
+
+	handler := uipath.JobHandler{Client: suite.c, FolderId: folder.ID}
+
+	_, err := handler.Start(uipath.StartJobsInfo{ReleaseKey: "unknown"})
+	assert.ErrorIs(suite.T(), err, uipath.ErrNotFound)
+
+	jobs, err := handler.Start(uipath.StartJobsInfo{ReleaseKey: release.Key, JobsCount: 2})
+	suite.Require().NoError(err)
+	suite.Require().Len(jobs, 2)
+	assert.Equal(suite.T(), uipath.JobStatePending, jobs[0].State)
+
+	suite.True(suite.s.SetJobState(jobs[0].ID, uipath.JobStateFaulted, "robot crashed"))
+
+	job, err := handler.Wait(jobs[0].ID, time.Millisecond)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "robot crashed", job.Info)
+	assert.NotEmpty(suite.T(), job.EndTime)
+
+	found, count, err := handler.List(map[string]string{"$filter": "State eq 'Pending'"})
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, count)
+	assert.Equal(suite.T(), jobs[1].ID, found[0].ID)
+}
+
+func (suite *ServerTestSuite) TestFailAndRequests() {
+	handler := uipath.AssetHandler{Client: suite.c, FolderId: suite.s.DefaultFolder.ID}
+
+	suite.s.Fail(Failure{Path: uipath.AssetEndpoint, StatusCode: http.StatusTooManyRequests, Times: 2})
+
+	_, _, err := handler.List(nil)
+	assert.ErrorIs(suite.T(), err, uipath.ErrRateLimited)
+
+	_, _, err = handler.List(nil)
+	assert.ErrorIs(suite.T(), err, uipath.ErrRateLimited)
+
+	_, _, err = handler.List(nil)
+	suite.Require().NoError(err)
+
+	var paths []string
+	for _, request := range suite.s.Requests() {
+		paths = append(paths, request.Path)
+	}
+
+	assert.Equal(suite.T(), TokenPath+" Assets Assets Assets", strings.Join(paths, " "))
+}
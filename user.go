@@ -0,0 +1,291 @@
+package uipath
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	UserTypeUser           = "User"
+	UserTypeRobot          = "Robot"
+	UserTypeDirectoryUser  = "DirectoryUser"
+	UserTypeDirectoryGroup = "DirectoryGroup"
+
+	UserEndpoint                   = "Users"
+	UserAssignRolesEndpoint        = "UiPath.Server.Configuration.OData.AssignRoles"
+	UserCurrentEndpoint            = "Users/UiPath.Server.Configuration.OData.GetCurrentUser"
+	UserCurrentPermissionsEndpoint = "Users/UiPath.Server.Configuration.OData.GetCurrentPermissions"
+	FolderAssignUsersEndpoint      = "Folders/UiPath.Server.Configuration.OData.AssignUsers"
+	FolderGetUsersEndpoint         = "Folders/UiPath.Server.Configuration.OData.GetUsersForFolder"
+	FolderRemoveUserEndpoint       = "UiPath.Server.Configuration.OData.RemoveUserFromFolder"
+)
+
+// UserHandler struct defines what the user handler looks like
+type UserHandler struct {
+	Client   *Client
+	FolderId uint
+}
+
+// User struct defines what the user model looks like, IsActive is a pointer so Update can deactivate
+// a user while a nil IsActive is left out of the request
+type User struct {
+	ID                   uint       `json:"Id,omitempty"`
+	Key                  string     `json:"Key,omitempty"`
+	Name                 string     `json:"Name,omitempty"`
+	Surname              string     `json:"Surname,omitempty"`
+	UserName             string     `json:"UserName"`
+	Domain               string     `json:"Domain,omitempty"`
+	DirectoryIdentifier  string     `json:"DirectoryIdentifier,omitempty"`
+	FullName             string     `json:"FullName,omitempty"`
+	EmailAddress         string     `json:"EmailAddress,omitempty"`
+	IsActive             *bool      `json:"IsActive,omitempty"`
+	Type                 string     `json:"Type,omitempty"`
+	ProvisionType        string     `json:"ProvisionType,omitempty"`
+	AuthenticationSource string     `json:"AuthenticationSource,omitempty"`
+	LastLoginTime        string     `json:"LastLoginTime,omitempty"`
+	CreationTime         string     `json:"CreationTime,omitempty"`
+	RolesList            []string   `json:"RolesList,omitempty"`
+	UserRoles            []UserRole `json:"UserRoles,omitempty"`
+	MayHaveUserSession   bool       `json:"MayHaveUserSession,omitempty"`
+	MayHaveRobotSession  bool       `json:"MayHaveRobotSession,omitempty"`
+	MayHaveUnattended    bool       `json:"MayHaveUnattendedSession,omitempty"`
+}
+
+// UserRole struct defines a role assigned to a user
+type UserRole struct {
+	ID       uint   `json:"Id,omitempty"`
+	UserID   uint   `json:"UserId"`
+	RoleID   uint   `json:"RoleId"`
+	UserName string `json:"UserName,omitempty"`
+	RoleName string `json:"RoleName,omitempty"`
+	RoleType string `json:"RoleType,omitempty"`
+}
+
+// UserList struct defines what the user list looks like
+type UserList struct {
+	Count int    `json:"@odata.count"`
+	Value []User `json:"value"`
+}
+
+// UserAssignRolesRequest defines how the request looks like when assigning roles to a user
+type UserAssignRolesRequest struct {
+	RoleIDs []uint `json:"roleIds"`
+}
+
+// UserPermissions struct defines the permissions granted to the current user
+type UserPermissions struct {
+	UserID          uint         `json:"UserId"`
+	IsAdministrator bool         `json:"IsAdministrator"`
+	Permissions     []Permission `json:"Permissions"`
+}
+
+// FolderRoles defines the roles a user gets in a folder
+type FolderRoles struct {
+	FolderID uint   `json:"FolderId"`
+	RoleIDs  []uint `json:"RoleIds"`
+}
+
+// FolderAssignUsersRequest defines how the request looks like when adding users to folders
+type FolderAssignUsersRequest struct {
+	Assignments FolderUserAssignments `json:"assignments"`
+}
+
+// FolderUserAssignments defines the users and the roles they get per folder
+type FolderUserAssignments struct {
+	UserIDs        []uint        `json:"UserIds"`
+	RolesPerFolder []FolderRoles `json:"RolesPerFolder"`
+}
+
+// FolderUser struct defines a user and the roles it has in a folder
+type FolderUser struct {
+	User  User             `json:"UserEntity"`
+	Roles []FolderUserRole `json:"Roles"`
+}
+
+// FolderUserRole struct defines a role a user has in a folder
+type FolderUserRole struct {
+	ID     uint   `json:"Id"`
+	Name   string `json:"Name"`
+	Origin string `json:"Origin,omitempty"`
+}
+
+// FolderUserList struct defines what the folder user list looks like
+type FolderUserList struct {
+	Count int          `json:"@odata.count"`
+	Value []FolderUser `json:"value"`
+}
+
+// FolderRemoveUserRequest defines how the request looks like when removing a user from a folder
+type FolderRemoveUserRequest struct {
+	UserID uint `json:"userId"`
+}
+
+// IsGranted checks if the permission with the given name is granted
+func (p UserPermissions) IsGranted(name string) bool {
+	if p.IsAdministrator {
+		return true
+	}
+
+	for _, permission := range p.Permissions {
+		if permission.Name == name && permission.IsGranted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetByID fetches the user by id
+func (u *UserHandler) GetByID(ID uint) (User, error) {
+	var user User
+
+	url := fmt.Sprintf("%s%s(%d)", u.Client.BaseURL, UserEndpoint, ID)
+
+	resp, err := u.Client.SendWithAuthorization("GET", url, nil, u.buildHeaders(), map[string]string{})
+	if err != nil {
+		return user, err
+	}
+
+	err = json.Unmarshal(resp, &user)
+
+	return user, err
+}
+
+// List fetches a list of users that can be filtered using query parameters
+func (u *UserHandler) List(filters map[string]string) ([]User, int, error) {
+	var userList UserList
+
+	url := fmt.Sprintf("%s%s", u.Client.BaseURL, UserEndpoint)
+
+	resp, err := u.Client.SendWithAuthorization("GET", url, nil, u.buildHeaders(), filters)
+	if err != nil {
+		return userList.Value, userList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &userList)
+
+	return userList.Value, userList.Count, err
+}
+
+// Store creates a user on the orchestrator
+func (u *UserHandler) Store(user User) (User, error) {
+	var result User
+
+	url := fmt.Sprintf("%s%s", u.Client.BaseURL, UserEndpoint)
+
+	resp, err := u.Client.SendWithAuthorization("POST", url, user, u.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result, err
+}
+
+// Update updates a user
+func (u *UserHandler) Update(user User) (User, error) {
+	url := fmt.Sprintf("%s%s(%d)", u.Client.BaseURL, UserEndpoint, user.ID)
+
+	_, err := u.Client.SendWithAuthorization("PUT", url, user, u.buildHeaders(), map[string]string{})
+	if err != nil {
+		return user, err
+	}
+
+	return u.GetByID(user.ID)
+}
+
+// DeleteByID deletes a user by id
+func (u *UserHandler) DeleteByID(ID uint) error {
+	url := fmt.Sprintf("%s%s(%d)", u.Client.BaseURL, UserEndpoint, ID)
+
+	_, err := u.Client.SendWithAuthorization("DELETE", url, nil, u.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// AssignRoles replaces the tenant roles of a user
+func (u *UserHandler) AssignRoles(userID uint, roleIDs []uint) error {
+	url := fmt.Sprintf("%s%s(%d)/%s", u.Client.BaseURL, UserEndpoint, userID, UserAssignRolesEndpoint)
+
+	_, err := u.Client.SendWithAuthorization("POST", url, UserAssignRolesRequest{RoleIDs: roleIDs}, u.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// GetCurrent fetches the user the token belongs to
+func (u *UserHandler) GetCurrent() (User, error) {
+	var user User
+
+	url := fmt.Sprintf("%s%s", u.Client.BaseURL, UserCurrentEndpoint)
+
+	resp, err := u.Client.SendWithAuthorization("GET", url, nil, u.buildHeaders(), map[string]string{})
+	if err != nil {
+		return user, err
+	}
+
+	err = json.Unmarshal(resp, &user)
+
+	return user, err
+}
+
+// GetCurrentPermissions fetches the permissions granted to the token
+func (u *UserHandler) GetCurrentPermissions() (UserPermissions, error) {
+	var permissions UserPermissions
+
+	url := fmt.Sprintf("%s%s", u.Client.BaseURL, UserCurrentPermissionsEndpoint)
+
+	resp, err := u.Client.SendWithAuthorization("GET", url, nil, u.buildHeaders(), map[string]string{})
+	if err != nil {
+		return permissions, err
+	}
+
+	err = json.Unmarshal(resp, &permissions)
+
+	return permissions, err
+}
+
+// AssignToFolders adds users to folders with the given roles per folder
+func (u *UserHandler) AssignToFolders(userIDs []uint, rolesPerFolder []FolderRoles) error {
+	request := FolderAssignUsersRequest{
+		Assignments: FolderUserAssignments{
+			UserIDs:        userIDs,
+			RolesPerFolder: rolesPerFolder,
+		},
+	}
+
+	url := fmt.Sprintf("%s%s", u.Client.BaseURL, FolderAssignUsersEndpoint)
+
+	_, err := u.Client.SendWithAuthorization("POST", url, request, u.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// ListForFolder fetches the users of a folder and the roles they have in it
+func (u *UserHandler) ListForFolder(folderID uint, includeInherited bool, filters map[string]string) ([]FolderUser, int, error) {
+	var folderUserList FolderUserList
+
+	url := fmt.Sprintf("%s%s(key=%d,includeInherited=%t)", u.Client.BaseURL, FolderGetUsersEndpoint, folderID, includeInherited)
+
+	resp, err := u.Client.SendWithAuthorization("GET", url, nil, u.buildHeaders(), filters)
+	if err != nil {
+		return folderUserList.Value, folderUserList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &folderUserList)
+
+	return folderUserList.Value, folderUserList.Count, err
+}
+
+// RemoveFromFolder removes a user from a folder
+func (u *UserHandler) RemoveFromFolder(userID uint, folderID uint) error {
+	url := fmt.Sprintf("%sFolders(%d)/%s", u.Client.BaseURL, folderID, FolderRemoveUserEndpoint)
+
+	_, err := u.Client.SendWithAuthorization("POST", url, FolderRemoveUserRequest{UserID: userID}, u.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+func (u *UserHandler) buildHeaders() map[string]string {
+	return tenantHeaders(u.FolderId)
+}
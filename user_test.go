@@ -0,0 +1,139 @@
+package uipath
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/comvex-jp/uipath-go/configs"
+	"github.com/jarcoal/httpmock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserTestSuite struct {
+	suite.Suite
+	c *Client
+	h *UserHandler
+}
+
+func (suite *UserTestSuite) SetupTest() {
+	suite.c = &Client{
+		HttpClient: &http.Client{Transport: httpmock.DefaultTransport},
+		BaseURL:    testBaseURL,
+		Cache:      cache.New(5*time.Minute, 10*time.Minute),
+	}
+	suite.c.Cache.Set(configs.UIPathOauthToken, "=testToken=", 5*time.Minute)
+
+	suite.h = &UserHandler{Client: suite.c}
+
+	httpmock.Activate()
+}
+
+func (suite *UserTestSuite) TearDownTest() {
+	httpmock.DeactivateAndReset()
+	suite.c.Cache.Flush()
+}
+
+func TestUser(t *testing.T) {
+	suite.Run(t, new(UserTestSuite))
+}
+
+func (suite *UserTestSuite) TestUpdateDeactivatesUser() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s%s(5)", testBaseURL, UserEndpoint), func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s%s(5)", testBaseURL, UserEndpoint), httpmock.NewStringResponder(200, `{"Id":5,"UserName":"robot","IsActive":false}`))
+
+	inactive := false
+
+	user, err := suite.h.Update(User{ID: 5, UserName: "robot", IsActive: &inactive})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), false, sent["IsActive"])
+	assert.False(suite.T(), *user.IsActive)
+
+	sent = nil
+
+	_, err = suite.h.Update(User{ID: 5, UserName: "robot"})
+
+	assert.Nil(suite.T(), err)
+	assert.NotContains(suite.T(), sent, "IsActive")
+}
+
+func (suite *UserTestSuite) TestAssignRoles() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s%s(5)/%s", testBaseURL, UserEndpoint, UserAssignRolesEndpoint), func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := suite.h.AssignRoles(5, []uint{1, 2})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), map[string]interface{}{"roleIds": []interface{}{float64(1), float64(2)}}, sent)
+}
+
+func (suite *UserTestSuite) TestAssignToFolders() {
+	var sent map[string]interface{}
+
+	httpmock.RegisterResponder("POST", testBaseURL+FolderAssignUsersEndpoint, func(req *http.Request) (*http.Response, error) {
+		decodeBody(req, &sent)
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := suite.h.AssignToFolders([]uint{5}, []FolderRoles{{FolderID: 3, RoleIDs: []uint{8}}})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), map[string]interface{}{
+		"assignments": map[string]interface{}{
+			"UserIds":        []interface{}{float64(5)},
+			"RolesPerFolder": []interface{}{map[string]interface{}{"FolderId": float64(3), "RoleIds": []interface{}{float64(8)}}},
+		},
+	}, sent)
+}
+
+func (suite *UserTestSuite) TestListForFolder() {
+	var folder string
+
+	url := fmt.Sprintf("%s%s(key=3,includeInherited=true)", testBaseURL, FolderGetUsersEndpoint)
+
+	httpmock.RegisterResponder("GET", url, func(req *http.Request) (*http.Response, error) {
+		folder = req.Header.Get(HeaderOrganizationUnitId)
+
+		return httpmock.NewStringResponse(200, `{"@odata.count":1,"value":[{"UserEntity":{"Id":5,"UserName":"robot"},"Roles":[{"Id":8,"Name":"Folder Administrator","Origin":"Assigned"}]}]}`), nil
+	})
+
+	users, count, err := suite.h.ListForFolder(3, true, map[string]string{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, count)
+	assert.Equal(suite.T(), "robot", users[0].User.UserName)
+	assert.Equal(suite.T(), "Folder Administrator", users[0].Roles[0].Name)
+	assert.Empty(suite.T(), folder)
+}
+
+func (suite *UserTestSuite) TestGetCurrentPermissions() {
+	httpmock.RegisterResponder("GET", testBaseURL+UserCurrentPermissionsEndpoint, httpmock.NewStringResponder(200,
+		`{"UserId":5,"IsAdministrator":false,"Permissions":[{"Name":"Assets.View","IsGranted":true},{"Name":"Assets.Edit","IsGranted":false}]}`))
+
+	permissions, err := suite.h.GetCurrentPermissions()
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), uint(5), permissions.UserID)
+	assert.True(suite.T(), permissions.IsGranted("Assets.View"))
+	assert.False(suite.T(), permissions.IsGranted("Assets.Edit"))
+	assert.False(suite.T(), permissions.IsGranted("Queues.View"))
+
+	permissions.IsAdministrator = true
+	assert.True(suite.T(), permissions.IsGranted("Queues.View"))
+}
@@ -0,0 +1,348 @@
+package uipath
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	EventJobCreated   = "job.created"
+	EventJobStarted   = "job.started"
+	EventJobCompleted = "job.completed"
+	EventJobFaulted   = "job.faulted"
+	EventJobStopped   = "job.stopped"
+
+	EventQueueItemAdded                = "queueItem.added"
+	EventQueueItemTransactionStarted   = "queueItem.transactionStarted"
+	EventQueueItemTransactionCompleted = "queueItem.transactionCompleted"
+	EventQueueItemTransactionFailed    = "queueItem.transactionFailed"
+	EventQueueItemTransactionAbandoned = "queueItem.transactionAbandoned"
+
+	HeaderWebhookSignature = "X-UiPath-Signature"
+
+	// DefaultWebhookMaxBodySize is the largest payload a listener reads when MaxBodySize is not set
+	DefaultWebhookMaxBodySize = 1 << 20
+
+	WebhookEndpoint           = "Webhooks"
+	WebhookEventTypesEndpoint = "Webhooks/UiPath.Server.Configuration.OData.GetEventTypes"
+)
+
+// ErrInvalidWebhookSignature is returned when the payload signature does not match the webhook secret
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// ErrWebhookBodyTooLarge is returned when a payload is larger than the MaxBodySize of the listener
+var ErrWebhookBodyTooLarge = errors.New("webhook body too large")
+
+// WebhookHandler struct defines what the webhook handler looks like
+type WebhookHandler struct {
+	Client *Client
+}
+
+// Webhook struct defines what the webhook subscription model looks like
+type Webhook struct {
+	ID                   uint                 `json:"Id,omitempty"`
+	Key                  string               `json:"Key,omitempty"`
+	Name                 string               `json:"Name"`
+	Description          string               `json:"Description,omitempty"`
+	URL                  string               `json:"Url"`
+	Enabled              bool                 `json:"Enabled"`
+	Secret               string               `json:"Secret,omitempty"`
+	SubscribeToAllEvents bool                 `json:"SubscribeToAllEvents"`
+	AllowInsecureSsl     bool                 `json:"AllowInsecureSsl"`
+	Events               []WebhookEventFilter `json:"Events"`
+}
+
+// WebhookEventFilter struct defines an event type a webhook is subscribed to
+type WebhookEventFilter struct {
+	EventType string `json:"EventType"`
+}
+
+// WebhookList struct defines what the webhook list looks like
+type WebhookList struct {
+	Count int       `json:"@odata.count"`
+	Value []Webhook `json:"value"`
+}
+
+// WebhookEventType struct defines an event type supported by the orchestrator
+type WebhookEventType struct {
+	Name  string `json:"Name"`
+	Group string `json:"Group"`
+}
+
+// WebhookEventTypeList struct defines what the event type list looks like
+type WebhookEventTypeList struct {
+	Value []WebhookEventType `json:"value"`
+}
+
+// WebhookEvent struct defines the payload sent by the orchestrator to a webhook
+type WebhookEvent struct {
+	Type               string          `json:"Type"`
+	EventID            string          `json:"EventId"`
+	Timestamp          string          `json:"Timestamp"`
+	TenantID           uint            `json:"TenantId,omitempty"`
+	OrganizationUnitID uint            `json:"OrganizationUnitId,omitempty"`
+	UserID             uint            `json:"UserId,omitempty"`
+	Job                *Job            `json:"Job,omitempty"`
+	Jobs               []Job           `json:"Jobs,omitempty"`
+	QueueItem          *QueueItem      `json:"QueueItem,omitempty"`
+	QueueItems         []QueueItem     `json:"QueueItems,omitempty"`
+	Raw                json.RawMessage `json:"-"`
+}
+
+// WebhookCallback is called for every verified event of the type it was registered for
+type WebhookCallback func(event WebhookEvent) error
+
+// WebhookListener verifies and dispatches the events sent by the orchestrator
+type WebhookListener struct {
+	Secret string
+
+	// MaxBodySize limits the payloads read before the signature is checked, it defaults to DefaultWebhookMaxBodySize
+	MaxBodySize int64
+
+	mu        sync.RWMutex
+	callbacks map[string][]WebhookCallback
+}
+
+// GetByID fetches the webhook by id
+func (w *WebhookHandler) GetByID(ID uint) (Webhook, error) {
+	var webhook Webhook
+
+	url := fmt.Sprintf("%s%s(%d)", w.Client.BaseURL, WebhookEndpoint, ID)
+
+	resp, err := w.Client.SendWithAuthorization("GET", url, nil, w.buildHeaders(), map[string]string{})
+	if err != nil {
+		return webhook, err
+	}
+
+	err = json.Unmarshal(resp, &webhook)
+
+	return webhook, err
+}
+
+// List fetches a list of webhooks that can be filtered using query parameters
+func (w *WebhookHandler) List(filters map[string]string) ([]Webhook, int, error) {
+	var webhookList WebhookList
+
+	url := fmt.Sprintf("%s%s", w.Client.BaseURL, WebhookEndpoint)
+
+	resp, err := w.Client.SendWithAuthorization("GET", url, nil, w.buildHeaders(), filters)
+	if err != nil {
+		return webhookList.Value, webhookList.Count, err
+	}
+
+	err = json.Unmarshal(resp, &webhookList)
+
+	return webhookList.Value, webhookList.Count, err
+}
+
+// Store creates a webhook subscription on the orchestrator
+func (w *WebhookHandler) Store(webhook Webhook) (Webhook, error) {
+	var result Webhook
+
+	url := fmt.Sprintf("%s%s", w.Client.BaseURL, WebhookEndpoint)
+
+	resp, err := w.Client.SendWithAuthorization("POST", url, webhook, w.buildHeaders(), map[string]string{})
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(resp, &result)
+
+	return result, err
+}
+
+// Update updates a webhook subscription
+func (w *WebhookHandler) Update(webhook Webhook) (Webhook, error) {
+	url := fmt.Sprintf("%s%s(%d)", w.Client.BaseURL, WebhookEndpoint, webhook.ID)
+
+	_, err := w.Client.SendWithAuthorization("PUT", url, webhook, w.buildHeaders(), map[string]string{})
+	if err != nil {
+		return webhook, err
+	}
+
+	return w.GetByID(webhook.ID)
+}
+
+// DeleteByID deletes a webhook subscription by id
+func (w *WebhookHandler) DeleteByID(ID uint) error {
+	url := fmt.Sprintf("%s%s(%d)", w.Client.BaseURL, WebhookEndpoint, ID)
+
+	_, err := w.Client.SendWithAuthorization("DELETE", url, nil, w.buildHeaders(), map[string]string{})
+
+	return err
+}
+
+// GetEventTypes fetches the event types a webhook can subscribe to
+func (w *WebhookHandler) GetEventTypes() ([]WebhookEventType, error) {
+	var eventTypeList WebhookEventTypeList
+
+	url := fmt.Sprintf("%s%s", w.Client.BaseURL, WebhookEventTypesEndpoint)
+
+	resp, err := w.Client.SendWithAuthorization("GET", url, nil, w.buildHeaders(), map[string]string{})
+	if err != nil {
+		return eventTypeList.Value, err
+	}
+
+	err = json.Unmarshal(resp, &eventTypeList)
+
+	return eventTypeList.Value, err
+}
+
+func (w *WebhookHandler) buildHeaders() map[string]string {
+	return map[string]string{}
+}
+
+// SignWebhookPayload computes the signature the orchestrator sends for a payload
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks the payload against the signature from the X-UiPath-Signature header
+func VerifyWebhookSignature(secret string, payload []byte, signature string) error {
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	if !hmac.Equal(expected, mac.Sum(nil)) {
+		return ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+// ParseWebhookEvent decodes a webhook payload into a typed event
+func ParseWebhookEvent(payload []byte) (WebhookEvent, error) {
+	var event WebhookEvent
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return event, err
+	}
+
+	event.Raw = json.RawMessage(payload)
+
+	return event, nil
+}
+
+// NewWebhookListener creates a listener that verifies payloads with the given secret
+func NewWebhookListener(secret string) *WebhookListener {
+	return &WebhookListener{
+		Secret:    secret,
+		callbacks: map[string][]WebhookCallback{},
+	}
+}
+
+// On registers a callback for an event type, use "*" to receive every event
+func (l *WebhookListener) On(eventType string, callback WebhookCallback) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.callbacks == nil {
+		l.callbacks = map[string][]WebhookCallback{}
+	}
+
+	l.callbacks[eventType] = append(l.callbacks[eventType], callback)
+}
+
+// Dispatch calls the callbacks registered for the type of the event
+func (l *WebhookListener) Dispatch(event WebhookEvent) error {
+	l.mu.RLock()
+	callbacks := append([]WebhookCallback{}, l.callbacks[event.Type]...)
+	callbacks = append(callbacks, l.callbacks["*"]...)
+	l.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		if err := callback(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ServeHTTP verifies, decodes and dispatches the event sent by the orchestrator
+func (l *WebhookListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := l.readVerifiedBody(r)
+	if err != nil {
+		writeWebhookBodyError(w, err)
+		return
+	}
+
+	event, err := ParseWebhookEvent(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := l.Dispatch(event); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Middleware rejects requests with an invalid signature before passing them to the next handler
+func (l *WebhookListener) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := l.readVerifiedBody(r)
+		if err != nil {
+			writeWebhookBodyError(w, err)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(payload))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *WebhookListener) readVerifiedBody(r *http.Request) ([]byte, error) {
+	maxBodySize := l.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultWebhookMaxBodySize
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return payload, err
+	}
+
+	if int64(len(payload)) > maxBodySize {
+		return nil, ErrWebhookBodyTooLarge
+	}
+
+	if err := VerifyWebhookSignature(l.Secret, payload, r.Header.Get(HeaderWebhookSignature)); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+func writeWebhookBodyError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrWebhookBodyTooLarge) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusUnauthorized)
+}
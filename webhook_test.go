@@ -0,0 +1,120 @@
+package uipath
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const testWebhookSecret = "=testSecret="
+
+type WebhookTestSuite struct {
+	suite.Suite
+	l *WebhookListener
+}
+
+func (suite *WebhookTestSuite) SetupTest() {
+	suite.l = NewWebhookListener(testWebhookSecret)
+}
+
+func TestWebhook(t *testing.T) {
+	suite.Run(t, new(WebhookTestSuite))
+}
+
+func (suite *WebhookTestSuite) TestVerifyWebhookSignature() {
+	payload := []byte(`{"Type":"job.completed"}`)
+
+	assert.Nil(suite.T(), VerifyWebhookSignature(testWebhookSecret, payload, SignWebhookPayload(testWebhookSecret, payload)))
+	assert.Equal(suite.T(), ErrInvalidWebhookSignature, VerifyWebhookSignature("otherSecret", payload, SignWebhookPayload(testWebhookSecret, payload)))
+	assert.Equal(suite.T(), ErrInvalidWebhookSignature, VerifyWebhookSignature(testWebhookSecret, payload, "not base64"))
+}
+
+func (suite *WebhookTestSuite) TestServeHTTPDispatchesTypedEvent() {
+	payload := []byte(`{"Type":"queueItem.transactionCompleted","EventId":"abc","QueueItem":{"Id":12,"Status":"Successful","Name":"Invoices"}}`)
+
+	var received WebhookEvent
+	var wildcardCalls int
+
+	suite.l.On(EventQueueItemTransactionCompleted, func(event WebhookEvent) error {
+		received = event
+		return nil
+	})
+	suite.l.On("*", func(event WebhookEvent) error {
+		wildcardCalls++
+		return nil
+	})
+	suite.l.On(EventJobCompleted, func(event WebhookEvent) error {
+		suite.T().Fatal("unexpected job callback")
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(payload))
+	req.Header.Set(HeaderWebhookSignature, SignWebhookPayload(testWebhookSecret, payload))
+
+	suite.l.ServeHTTP(rec, req)
+
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "abc", received.EventID)
+	assert.Equal(suite.T(), uint(12), received.QueueItem.ID)
+	assert.Equal(suite.T(), "Successful", received.QueueItem.Status)
+	assert.Equal(suite.T(), 1, wildcardCalls)
+}
+
+func (suite *WebhookTestSuite) TestServeHTTPRejectsInvalidSignature() {
+	payload := []byte(`{"Type":"job.completed"}`)
+
+	suite.l.On(EventJobCompleted, func(event WebhookEvent) error {
+		suite.T().Fatal("callback must not be called")
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(payload))
+	req.Header.Set(HeaderWebhookSignature, SignWebhookPayload("wrongSecret", payload))
+
+	suite.l.ServeHTTP(rec, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, rec.Code)
+}
+
+func (suite *WebhookTestSuite) TestRejectsBodiesLargerThanMaxBodySize() {
+	payload := []byte(`{"Type":"job.completed","EventId":"` + strings.Repeat("a", 64) + `"}`)
+
+	suite.l.MaxBodySize = int64(len(payload) - 1)
+	suite.l.On(EventJobCompleted, func(event WebhookEvent) error {
+		suite.T().Fatal("callback must not be called")
+		return nil
+	})
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.T().Fatal("next handler must not be called")
+	})
+
+	for _, handler := range []http.Handler{suite.l, suite.l.Middleware(next)} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(payload))
+		req.Header.Set(HeaderWebhookSignature, SignWebhookPayload(testWebhookSecret, payload))
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, rec.Code)
+	}
+
+	suite.l.MaxBodySize = int64(len(payload))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(payload))
+	req.Header.Set(HeaderWebhookSignature, SignWebhookPayload(testWebhookSecret, payload))
+
+	suite.l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})).ServeHTTP(rec, req)
+
+	assert.Equal(suite.T(), http.StatusAccepted, rec.Code)
+}
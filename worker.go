@@ -0,0 +1,278 @@
+package uipath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWorkerMinBackoff = time.Second
+	defaultWorkerMaxBackoff = 30 * time.Second
+)
+
+// ErrNoTransaction is returned by ReportProgress outside of a worker transaction
+var ErrNoTransaction = errors.New("uipath: no transaction in context")
+
+// WorkerFunc processes a queue item and returns its output. Returning a BusinessError fails the
+// item with a business exception, any other error fails it with an application exception.
+type WorkerFunc func(ctx context.Context, item QueueItem) (map[string]interface{}, error)
+
+// BusinessError fails a transaction with a business exception, the data of the item is invalid and
+// retrying it will not help
+type BusinessError struct {
+	Reason  string
+	Details string
+}
+
+// Worker consumes a queue: it starts transactions, runs Process on them and sets their result.
+// When its context is canceled it stops starting transactions and waits for the running ones to end.
+type Worker struct {
+	Handler *QueueItemHandler
+	Queue   string
+	Process WorkerFunc
+
+	// Concurrency is the number of transactions processed at once, it defaults to 1
+	Concurrency int
+
+	// RobotIdentifier is sent when starting transactions, it is needed by some robot based setups
+	RobotIdentifier string
+
+	// MinBackoff and MaxBackoff bound the wait when the queue is empty or failing, it doubles until
+	// MaxBackoff and resets once an item is processed. They default to 1s and 30s.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// DrainTimeout cancels the context of the running transactions once the worker is stopped for that
+	// long, zero waits for them to end
+	DrainTimeout time.Duration
+
+	// OnError is called when a transaction cannot be started or ended
+	OnError func(err error)
+}
+
+// transactionKey is the context key of the running transaction
+type transactionKey struct{}
+
+// transaction is the queue item being processed and how to report its progress
+type transaction struct {
+	handler *QueueItemHandler
+	item    QueueItem
+}
+
+// workerPanic is the error of a worker func that panicked
+type workerPanic struct {
+	value interface{}
+	stack []byte
+}
+
+// detachedContext keeps the values of its parent but is never canceled with it
+type detachedContext struct {
+	context.Context
+}
+
+// NewBusinessError creates a business error
+func NewBusinessError(format string, args ...interface{}) *BusinessError {
+	return &BusinessError{Reason: fmt.Sprintf(format, args...)}
+}
+
+func (e *BusinessError) Error() string {
+	if e.Details != "" {
+		return e.Reason + ": " + e.Details
+	}
+
+	return e.Reason
+}
+
+func (p *workerPanic) Error() string {
+	return fmt.Sprintf("panic: %v", p.value)
+}
+
+// ReportProgress sets the progress of the transaction processed with ctx
+func ReportProgress(ctx context.Context, progress string) error {
+	t, ok := ctx.Value(transactionKey{}).(*transaction)
+	if !ok {
+		return ErrNoTransaction
+	}
+
+	return t.handler.SetTransactionProgress(t.item.ID, progress)
+}
+
+// TransactionFromContext returns the queue item processed with ctx
+func TransactionFromContext(ctx context.Context) (QueueItem, bool) {
+	t, ok := ctx.Value(transactionKey{}).(*transaction)
+	if !ok {
+		return QueueItem{}, false
+	}
+
+	return t.item, true
+}
+
+// Run processes the queue until ctx is done, then waits for the running transactions and returns ctx.Err()
+func (w *Worker) Run(ctx context.Context) error {
+	concurrency := w.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	// The transactions run and end with a context that outlives ctx so they are drained
+	processCtx, cancelProcess := context.WithCancel(detachedContext{ctx})
+	defer cancelProcess()
+
+	if w.DrainTimeout > 0 {
+		stop := make(chan struct{})
+		defer close(stop)
+
+		go func() {
+			select {
+			case <-ctx.Done():
+			case <-stop:
+				return
+			}
+
+			timer := time.NewTimer(w.DrainTimeout)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+				cancelProcess()
+			case <-stop:
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			w.loop(ctx, processCtx)
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// loop starts transactions until ctx is done, they are started with processCtx so a transaction
+// started while ctx is canceled is still processed instead of being left in progress
+func (w *Worker) loop(ctx context.Context, processCtx context.Context) {
+	starter := QueueItemHandler{Client: w.Handler.Client.WithContext(processCtx), FolderId: w.Handler.FolderId}
+	backoff := time.Duration(0)
+
+	for ctx.Err() == nil {
+		item, err := starter.StartTransaction(TransactionData{Name: w.Queue, RobotIdentifier: w.RobotIdentifier})
+		if err != nil && ctx.Err() == nil {
+			w.error(fmt.Errorf("starting a transaction on %s: %w", w.Queue, err))
+		}
+
+		if err != nil || item.ID == 0 {
+			backoff = w.nextBackoff(backoff)
+
+			if sleepContext(ctx, backoff) != nil {
+				return
+			}
+
+			continue
+		}
+
+		backoff = 0
+
+		w.process(processCtx, item)
+	}
+}
+
+// process runs the worker func on the item and ends its transaction
+func (w *Worker) process(ctx context.Context, item QueueItem) {
+	handler := &QueueItemHandler{Client: w.Handler.Client.WithContext(ctx), FolderId: w.Handler.FolderId}
+
+	ctx = context.WithValue(ctx, transactionKey{}, &transaction{handler: handler, item: item})
+
+	output, err := w.call(ctx, item)
+
+	result := TransactionResult{IsSuccessful: err == nil, Output: output}
+
+	var businessError *BusinessError
+	var panicked *workerPanic
+
+	switch {
+	case err == nil:
+	case errors.As(err, &businessError):
+		result.ProcessingException = &TransactionException{
+			Reason:  businessError.Reason,
+			Details: businessError.Details,
+			Type:    ExceptionTypeBusiness,
+		}
+	case errors.As(err, &panicked):
+		result.ProcessingException = &TransactionException{
+			Reason:  panicked.Error(),
+			Details: string(panicked.stack),
+			Type:    ExceptionTypeApplication,
+		}
+	default:
+		result.ProcessingException = &TransactionException{
+			Reason: err.Error(),
+			Type:   ExceptionTypeApplication,
+		}
+	}
+
+	// The result is set even once the drain timeout canceled ctx, the item would stay in progress otherwise
+	ender := &QueueItemHandler{Client: w.Handler.Client.WithContext(detachedContext{ctx}), FolderId: w.Handler.FolderId}
+
+	if err := ender.SetTransactionResult(item.ID, result); err != nil {
+		w.error(fmt.Errorf("setting the result of queue item %d: %w", item.ID, err))
+	}
+}
+
+// call runs the worker func, turning its panics into application exceptions
+func (w *Worker) call(ctx context.Context, item QueueItem) (output map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			output = nil
+			err = &workerPanic{value: r, stack: debug.Stack()}
+		}
+	}()
+
+	return w.Process(ctx, item)
+}
+
+func (w *Worker) nextBackoff(backoff time.Duration) time.Duration {
+	minBackoff := w.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultWorkerMinBackoff
+	}
+
+	maxBackoff := w.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultWorkerMaxBackoff
+	}
+
+	backoff *= 2
+	if backoff < minBackoff {
+		backoff = minBackoff
+	}
+
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	return backoff
+}
+
+func (w *Worker) error(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
@@ -0,0 +1,323 @@
+package uipath
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *QueueItemTestSuite) TestWorkerProcessesAndDrains() {
+	var mu sync.Mutex
+	var results = map[uint]TransactionResult{}
+	var progress []string
+
+	pending := []QueueItem{{ID: 1, Reference: "ok"}, {ID: 2, Reference: "business"}, {ID: 3, Reference: "application"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpmock.RegisterResponder("POST", testBaseURL+QueueStartTransactionEndpoint, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(pending) == 0 {
+			return httpmock.NewStringResponse(204, ""), nil
+		}
+
+		item := pending[0]
+		pending = pending[1:]
+
+		return httpmock.NewJsonResponse(200, item)
+	})
+
+	for id := 1; id <= 3; id++ {
+		id := uint(id)
+
+		httpmock.RegisterResponder("POST", fmt.Sprintf("%s%s(%d)/%s", testBaseURL, QueueItemEndpoint, id, QueueSetTransactionResultEndpoint), func(req *http.Request) (*http.Response, error) {
+			var body TransactionResultRequest
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			results[id] = body.TransactionResult
+			if len(results) == 3 {
+				cancel()
+			}
+
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+
+		httpmock.RegisterResponder("POST", fmt.Sprintf("%s%s(%d)/%s", testBaseURL, QueueItemEndpoint, id, QueueSetTransactionProgressEndpoint), func(req *http.Request) (*http.Response, error) {
+			var body TransactionProgressRequest
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			progress = append(progress, body.Progress)
+
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+	}
+
+	worker := Worker{
+		Handler:     suite.h,
+		Queue:       "Invoices",
+		Concurrency: 2,
+		MinBackoff:  5 * time.Millisecond,
+		MaxBackoff:  20 * time.Millisecond,
+		Process: func(ctx context.Context, item QueueItem) (map[string]interface{}, error) {
+			switch item.Reference {
+			case "business":
+				return nil, &BusinessError{Reason: "Unknown vendor", Details: "vendor 12"}
+			case "application":
+				return nil, errors.New("erp is down")
+			}
+
+			if err := ReportProgress(ctx, "halfway"); err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"ErpId": "X9"}, nil
+		},
+	}
+
+	err := worker.Run(ctx)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Equal(suite.T(), TransactionResult{IsSuccessful: true, Output: map[string]interface{}{"ErpId": "X9"}}, results[1])
+	assert.Equal(suite.T(), &TransactionException{Reason: "Unknown vendor", Details: "vendor 12", Type: ExceptionTypeBusiness}, results[2].ProcessingException)
+	assert.Equal(suite.T(), &TransactionException{Reason: "erp is down", Type: ExceptionTypeApplication}, results[3].ProcessingException)
+	assert.Equal(suite.T(), []string{"halfway"}, progress)
+}
+
+func (suite *QueueItemTestSuite) TestReportProgressOutsideOfTransaction() {
+	assert.ErrorIs(suite.T(), ReportProgress(context.Background(), "halfway"), ErrNoTransaction)
+}
+
+func (suite *QueueItemTestSuite) TestWorkerProcessesTransactionStartedWhileStopping() {
+	var mu sync.Mutex
+	var results = map[uint]TransactionResult{}
+	var started bool
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpmock.RegisterResponder("POST", testBaseURL+QueueStartTransactionEndpoint, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		first := !started
+		started = true
+		mu.Unlock()
+
+		if !first {
+			return httpmock.NewStringResponse(204, ""), nil
+		}
+
+		// The worker is stopped while the transaction is being started
+		cancel()
+		time.Sleep(20 * time.Millisecond)
+
+		return httpmock.NewJsonResponse(200, QueueItem{ID: 1, Reference: "late"})
+	})
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s%s(1)/%s", testBaseURL, QueueItemEndpoint, QueueSetTransactionResultEndpoint), func(req *http.Request) (*http.Response, error) {
+		var body TransactionResultRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		results[1] = body.TransactionResult
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	worker := Worker{
+		Handler: suite.h,
+		Queue:   "Invoices",
+		Process: func(ctx context.Context, item QueueItem) (map[string]interface{}, error) {
+			return nil, nil
+		},
+	}
+
+	err := worker.Run(ctx)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Equal(suite.T(), TransactionResult{IsSuccessful: true}, results[1])
+}
+
+// registerWorkerItem makes the queue return item once and records the result set for it
+func (suite *QueueItemTestSuite) registerWorkerItem(item QueueItem, results chan<- TransactionResult) {
+	var mu sync.Mutex
+	var started bool
+
+	httpmock.RegisterResponder("POST", testBaseURL+QueueStartTransactionEndpoint, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if started {
+			return httpmock.NewStringResponse(204, ""), nil
+		}
+
+		started = true
+
+		return httpmock.NewJsonResponse(200, item)
+	})
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s%s(%d)/%s", testBaseURL, QueueItemEndpoint, item.ID, QueueSetTransactionResultEndpoint), func(req *http.Request) (*http.Response, error) {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		var body TransactionResultRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+
+		results <- body.TransactionResult
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+}
+
+func (suite *QueueItemTestSuite) TestWorkerDrainTimeoutCancelsRunningTransaction() {
+	results := make(chan TransactionResult, 1)
+	suite.registerWorkerItem(QueueItem{ID: 1}, results)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	worker := Worker{
+		Handler:      suite.h,
+		Queue:        "Invoices",
+		DrainTimeout: 20 * time.Millisecond,
+		Process: func(processCtx context.Context, item QueueItem) (map[string]interface{}, error) {
+			cancel()
+
+			select {
+			case <-processCtx.Done():
+				return nil, processCtx.Err()
+			case <-time.After(time.Second):
+				return nil, nil
+			}
+		},
+	}
+
+	err := worker.Run(ctx)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	if assert.Len(suite.T(), results, 1) {
+		assert.Equal(suite.T(), &TransactionException{Reason: context.Canceled.Error(), Type: ExceptionTypeApplication}, (<-results).ProcessingException)
+	}
+}
+
+func (suite *QueueItemTestSuite) TestWorkerWaitsForRunningTransactionWhenStopped() {
+	results := make(chan TransactionResult, 1)
+	suite.registerWorkerItem(QueueItem{ID: 1}, results)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	worker := Worker{
+		Handler: suite.h,
+		Queue:   "Invoices",
+		Process: func(processCtx context.Context, item QueueItem) (map[string]interface{}, error) {
+			cancel()
+
+			select {
+			case <-processCtx.Done():
+				return nil, processCtx.Err()
+			case <-time.After(20 * time.Millisecond):
+				return map[string]interface{}{"Done": true}, nil
+			}
+		},
+	}
+
+	err := worker.Run(ctx)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	if assert.Len(suite.T(), results, 1) {
+		assert.Equal(suite.T(), TransactionResult{IsSuccessful: true, Output: map[string]interface{}{"Done": true}}, <-results)
+	}
+}
+
+func (suite *QueueItemTestSuite) TestWorkerRecoversPanics() {
+	results := make(chan TransactionResult, 1)
+	suite.registerWorkerItem(QueueItem{ID: 1}, results)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	worker := Worker{
+		Handler: suite.h,
+		Queue:   "Invoices",
+		Process: func(processCtx context.Context, item QueueItem) (map[string]interface{}, error) {
+			cancel()
+			panic("nil vendor")
+		},
+	}
+
+	err := worker.Run(ctx)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	if assert.Len(suite.T(), results, 1) {
+		exception := (<-results).ProcessingException
+
+		assert.Equal(suite.T(), "panic: nil vendor", exception.Reason)
+		assert.Equal(suite.T(), ExceptionTypeApplication, exception.Type)
+		assert.Contains(suite.T(), exception.Details, "worker_test.go")
+	}
+}
+
+func (suite *QueueItemTestSuite) TestWorkerBacksOffOnEmptyQueue() {
+	var starts int32
+
+	httpmock.RegisterResponder("POST", testBaseURL+QueueStartTransactionEndpoint, func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&starts, 1)
+
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	worker := Worker{
+		Handler:    suite.h,
+		Queue:      "Invoices",
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 40 * time.Millisecond,
+		Process: func(ctx context.Context, item QueueItem) (map[string]interface{}, error) {
+			return nil, nil
+		},
+	}
+
+	err := worker.Run(ctx)
+
+	// The transactions are started at 0, 10, 30 and 70ms, waiting 10, 20 then 40ms
+	assert.ErrorIs(suite.T(), err, context.DeadlineExceeded)
+	assert.InDelta(suite.T(), 4, atomic.LoadInt32(&starts), 1)
+
+	backoff := time.Duration(0)
+	for _, expected := range []time.Duration{10, 20, 40, 40} {
+		backoff = worker.nextBackoff(backoff)
+		assert.Equal(suite.T(), expected*time.Millisecond, backoff)
+	}
+
+	assert.Equal(suite.T(), defaultWorkerMinBackoff, (&Worker{}).nextBackoff(0))
+	assert.Equal(suite.T(), defaultWorkerMaxBackoff, (&Worker{}).nextBackoff(time.Minute))
+}